@@ -2,9 +2,14 @@ package logger
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Config определяет настройки логгера.
@@ -13,12 +18,75 @@ type Config struct {
 	EnableOTLP         bool                                // Включить экспорт в OTLP.
 	EnableStdout       bool                                // Включить вывод в stdout.
 	Level              string                              // Уровень логирования (debug, info, warn, error).
+	StdoutLevel        string                              // Отдельный уровень для stdout (пусто — общий уровень).
+	OtlpLevel          string                              // Отдельный уровень для OTLP (пусто — общий уровень).
+	FileLevel          string                              // Отдельный уровень для файла (пусто — общий уровень).
+	FilePath           string                              // Путь к файлу логов (пусто — запись в файл отключена).
+	FileRotation       RotationConfig                      // Ротация и хранение файла логов.
 	OtlpEndpoint       string                              // Эндпоинт OTLP коллектора.
 	OtlpUseTLS         bool                                // Использовать TLS для OTLP.
+	OtlpProtocol       string                              // Протокол OTLP: grpc (по умолчанию) или http/protobuf.
+	OtlpExporter       OTLPExporterConfig                  // Настройки транспорта OTLP экспортера.
+	OtlpProcessorMode  string                              // Режим процессора: batch (по умолчанию) или simple.
+	OtlpBatch          BatchConfig                         // Настройки batch процессора.
+	OtlpEmitTimeout    time.Duration                       // Таймаут отправки одной записи (0 — 500мс).
+	OtlpFailurePolicy  OTLPFailurePolicy                   // Поведение, если OTLP core не удалось создать.
 	ServiceName        string                              // Имя сервиса для телеметрии.
+	ServiceVersion     string                              // Версия сервиса (по умолчанию из информации о сборке).
 	ServiceEnvironment string                              // Окружение сервиса (prod, dev).
 	ShutdownTimeout    time.Duration                       // Таймаут для shutdown OTLP.
+	Development        bool                                // Режим разработки: DPanic паникует.
 	FieldExtractors    []func(context.Context) []zap.Field // Кастомные функции для извлечения полей из контекста.
+	ResourceAttributes []attribute.KeyValue                // Дополнительные атрибуты ресурса.
+	Resource           *resource.Resource                  // Ресурс, объединяемый с обнаруженными атрибутами.
+	ResourceDetectors  ResourceDetectors                   // Включенные детекторы ресурса.
+	SlogDefault        bool                                // Установить логгер как slog.Default.
+	LogrVerbosity      int                                 // Максимальный V-уровень logr.
+	Cores              []zapcore.Core                      // Дополнительные cores; ограничиваются общим уровнем.
+	OtlpCustomExporter otelLogSdk.Exporter                 // Экспортер вместо создаваемого по OtlpProtocol.
+	Sampling           *SamplingConfig                     // Сэмплирование для всех приёмников.
+	SinkPolicies       map[Sink]SinkPolicy                 // Сэмплирование и ограничения отдельных приёмников.
+	SuppressedInterval time.Duration                       // Период сводки об отброшенных записях.
+	Redaction          *RedactionConfig                    // Скрытие секретов и персональных данных.
+
+	useEnv bool // Заполнять Config из переменных окружения (см. WithEnvConfig).
+}
+
+// OTLPExporterConfig определяет настройки транспорта OTLP экспортера.
+// Заданные CAFile, CertFile/KeyFile или ServerName включают TLS.
+type OTLPExporterConfig struct {
+	URLPath     string            // Путь URL для http/protobuf (по умолчанию /v1/logs).
+	Headers     map[string]string // Заголовки (метаданные gRPC), например для авторизации.
+	Compression string            // Сжатие: gzip или none.
+	Timeout     time.Duration     // Таймаут одного экспорта (0 — значение экспортера).
+	Retry       *RetryConfig      // Политика повторов (nil — значение экспортера).
+	CAFile      string            // PEM файл с CA для проверки сертификата коллектора.
+	CertFile    string            // PEM файл клиентского сертификата (mTLS).
+	KeyFile     string            // PEM файл ключа клиентского сертификата (mTLS).
+	ServerName  string            // Переопределение имени сервера для проверки сертификата.
+}
+
+// RetryConfig определяет политику повторов экспорта.
+type RetryConfig struct {
+	Enabled         bool          // Включить повторы.
+	InitialInterval time.Duration // Пауза после первой ошибки.
+	MaxInterval     time.Duration // Максимальная пауза между повторами.
+	MaxElapsedTime  time.Duration // Максимальное общее время попыток.
+}
+
+// Режимы процессора OTLP.
+const (
+	OTLPProcessorBatch  = "batch"  // Асинхронная отправка пачками.
+	OTLPProcessorSimple = "simple" // Синхронная отправка каждой записи.
+)
+
+// BatchConfig определяет настройки batch процессора.
+// Нулевые значения означают значения SDK по умолчанию.
+type BatchConfig struct {
+	MaxQueueSize       int           // Максимальный размер очереди.
+	ExportInterval     time.Duration // Интервал экспорта.
+	ExportTimeout      time.Duration // Таймаут экспорта пачки.
+	MaxExportBatchSize int           // Максимальный размер пачки.
 }
 
 // Option настраивает Config.
@@ -36,24 +104,222 @@ func WithEnableStdout(v bool) Option { return func(c *Config) { c.EnableStdout =
 // WithLevel устанавливает уровень логирования.
 func WithLevel(level string) Option { return func(c *Config) { c.Level = level } }
 
+// WithStdoutLevel устанавливает отдельный уровень для stdout.
+func WithStdoutLevel(level string) Option { return func(c *Config) { c.StdoutLevel = level } }
+
+// WithOTLPLevel устанавливает отдельный уровень для OTLP.
+func WithOTLPLevel(level string) Option { return func(c *Config) { c.OtlpLevel = level } }
+
+// WithFileLevel устанавливает отдельный уровень для файла.
+func WithFileLevel(level string) Option { return func(c *Config) { c.FileLevel = level } }
+
+// WithFileOutput включает запись в файл path с ротацией. Формат совпадает
+// с stdout (см. WithAsJSON); записи буферизуются, Sync сбрасывает буфер на диск.
+func WithFileOutput(path string, rotation RotationConfig) Option {
+	return func(c *Config) {
+		c.FilePath = path
+		c.FileRotation = rotation
+	}
+}
+
 // WithOTLPEndpoint устанавливает эндпоинт OTLP.
 func WithOTLPEndpoint(endpoint string) Option { return func(c *Config) { c.OtlpEndpoint = endpoint } }
 
 // WithOTLPUseTLS включает TLS для OTLP.
 func WithOTLPUseTLS(v bool) Option { return func(c *Config) { c.OtlpUseTLS = v } }
 
+// WithOTLPProtocol устанавливает протокол OTLP (grpc, http/protobuf).
+func WithOTLPProtocol(protocol string) Option { return func(c *Config) { c.OtlpProtocol = protocol } }
+
+// WithOTLPURLPath устанавливает путь URL для http/protobuf.
+func WithOTLPURLPath(path string) Option { return func(c *Config) { c.OtlpExporter.URLPath = path } }
+
+// WithOTLPHeaders добавляет заголовки к запросам OTLP.
+func WithOTLPHeaders(headers map[string]string) Option {
+	return func(c *Config) {
+		if c.OtlpExporter.Headers == nil {
+			c.OtlpExporter.Headers = make(map[string]string, len(headers))
+		}
+		for k, v := range headers {
+			c.OtlpExporter.Headers[k] = v
+		}
+	}
+}
+
+// WithOTLPCompression устанавливает сжатие для OTLP (gzip, none).
+func WithOTLPCompression(compression string) Option {
+	return func(c *Config) { c.OtlpExporter.Compression = compression }
+}
+
+// WithOTLPTimeout устанавливает таймаут экспорта OTLP.
+func WithOTLPTimeout(timeout time.Duration) Option {
+	return func(c *Config) { c.OtlpExporter.Timeout = timeout }
+}
+
+// WithOTLPRetry устанавливает политику повторов экспорта OTLP.
+func WithOTLPRetry(retry RetryConfig) Option {
+	return func(c *Config) { c.OtlpExporter.Retry = &retry }
+}
+
+// WithOTLPCAFile устанавливает CA для проверки сертификата коллектора.
+func WithOTLPCAFile(path string) Option { return func(c *Config) { c.OtlpExporter.CAFile = path } }
+
+// WithOTLPClientCert устанавливает клиентский сертификат и ключ для mTLS.
+func WithOTLPClientCert(certFile, keyFile string) Option {
+	return func(c *Config) {
+		c.OtlpExporter.CertFile = certFile
+		c.OtlpExporter.KeyFile = keyFile
+	}
+}
+
+// WithOTLPServerName переопределяет имя сервера для проверки сертификата.
+func WithOTLPServerName(name string) Option {
+	return func(c *Config) { c.OtlpExporter.ServerName = name }
+}
+
+// WithOTLPProcessorMode устанавливает режим процессора (batch, simple).
+func WithOTLPProcessorMode(mode string) Option {
+	return func(c *Config) { c.OtlpProcessorMode = mode }
+}
+
+// WithOTLPBatch устанавливает настройки batch процессора.
+func WithOTLPBatch(batch BatchConfig) Option { return func(c *Config) { c.OtlpBatch = batch } }
+
+// WithOTLPEmitTimeout устанавливает таймаут отправки одной записи.
+func WithOTLPEmitTimeout(timeout time.Duration) Option {
+	return func(c *Config) { c.OtlpEmitTimeout = timeout }
+}
+
+// WithOTLPFailurePolicy устанавливает поведение при ошибке создания OTLP core.
+func WithOTLPFailurePolicy(policy OTLPFailurePolicy) Option {
+	return func(c *Config) { c.OtlpFailurePolicy = policy }
+}
+
 // WithServiceName устанавливает имя сервиса.
 func WithServiceName(name string) Option { return func(c *Config) { c.ServiceName = name } }
 
+// WithServiceVersion устанавливает версию сервиса.
+func WithServiceVersion(version string) Option { return func(c *Config) { c.ServiceVersion = version } }
+
 // WithServiceEnvironment устанавливает окружение.
 func WithServiceEnvironment(env string) Option { return func(c *Config) { c.ServiceEnvironment = env } }
 
+// WithResourceAttributes добавляет атрибуты ресурса.
+func WithResourceAttributes(attrs ...attribute.KeyValue) Option {
+	return func(c *Config) { c.ResourceAttributes = append(c.ResourceAttributes, attrs...) }
+}
+
+// WithResource объединяет заданный ресурс с обнаруженными атрибутами.
+func WithResource(res *resource.Resource) Option { return func(c *Config) { c.Resource = res } }
+
+// WithResourceDetectors устанавливает набор детекторов ресурса.
+func WithResourceDetectors(detectors ResourceDetectors) Option {
+	return func(c *Config) { c.ResourceDetectors = detectors }
+}
+
 // WithShutdownTimeout устанавливает таймаут для shutdown.
 func WithShutdownTimeout(timeout time.Duration) Option {
 	return func(c *Config) { c.ShutdownTimeout = timeout }
 }
 
+// WithDevelopment включает режим разработки.
+func WithDevelopment(v bool) Option { return func(c *Config) { c.Development = v } }
+
+// WithSlogDefault устанавливает логгер как slog.Default при создании.
+func WithSlogDefault(v bool) Option { return func(c *Config) { c.SlogDefault = v } }
+
+// WithLogrVerbosity задает максимальный V-уровень для Logger.Logr.
+func WithLogrVerbosity(v int) Option { return func(c *Config) { c.LogrVerbosity = v } }
+
+// WithCore добавляет core, в который пишутся записи наравне с stdout и OTLP.
+func WithCore(core zapcore.Core) Option {
+	return func(c *Config) { c.Cores = append(c.Cores, core) }
+}
+
+// WithOTLPExporter задает готовый экспортер OTLP (например, in-memory в тестах).
+// Настройки протокола, эндпоинта и TLS при этом не используются.
+func WithOTLPExporter(exporter otelLogSdk.Exporter) Option {
+	return func(c *Config) { c.OtlpCustomExporter = exporter }
+}
+
+// WithSampling включает сэмплирование для всех приёмников.
+func WithSampling(initial, thereafter int, tick time.Duration) Option {
+	return func(c *Config) {
+		c.Sampling = &SamplingConfig{Initial: initial, Thereafter: thereafter, Tick: tick}
+	}
+}
+
+// WithSinkSampling включает сэмплирование для одного приёмника.
+func WithSinkSampling(sink Sink, initial, thereafter int, tick time.Duration) Option {
+	return func(c *Config) {
+		p := sinkPolicy(c, sink)
+		p.Sampling = &SamplingConfig{Initial: initial, Thereafter: thereafter, Tick: tick}
+		c.SinkPolicies[sink] = p
+	}
+}
+
+// WithRateLimit ограничивает приёмник rate записями в секунду со всплеском до burst.
+func WithRateLimit(sink Sink, rate float64, burst int) Option {
+	return func(c *Config) {
+		p := sinkPolicy(c, sink)
+		p.RateLimit = &RateLimitConfig{Rate: rate, Burst: burst}
+		c.SinkPolicies[sink] = p
+	}
+}
+
+// WithSuppressedInterval задает период сводки об отброшенных записях.
+func WithSuppressedInterval(d time.Duration) Option {
+	return func(c *Config) { c.SuppressedInterval = d }
+}
+
+// WithRedaction включает скрытие секретов в полях и сообщениях перед записью
+// во все приёмники. Конфигурация по умолчанию — DefaultRedactionConfig().
+func WithRedaction(cfg RedactionConfig) Option {
+	return func(c *Config) { c.Redaction = &cfg }
+}
+
+// sinkPolicy возвращает текущую политику приёмника, копируя карту политик,
+// чтобы не изменять Config, из которого скопирован c.
+func sinkPolicy(c *Config, sink Sink) SinkPolicy {
+	policies := make(map[Sink]SinkPolicy, len(c.SinkPolicies)+1)
+	for k, v := range c.SinkPolicies {
+		policies[k] = v
+	}
+	c.SinkPolicies = policies
+	return policies[sink]
+}
+
 // WithFieldExtractor добавляет кастомный extractor полей из контекста.
 func WithFieldExtractor(fn func(context.Context) []zap.Field) Option {
 	return func(c *Config) { c.FieldExtractors = append(c.FieldExtractors, fn) }
 }
+
+// WithEnvConfig включает чтение настроек из переменных окружения OTEL_* и OTELZAP_*.
+// Явно переданные опции имеют приоритет над окружением, окружение — над значениями
+// по умолчанию. Исключение — OTEL_SDK_DISABLED=true: OTLP отключается всегда.
+func WithEnvConfig() Option {
+	return func(c *Config) { c.useEnv = true }
+}
+
+// applyOptions применяет опции к cfg. WithEnvConfig отмечает Config полем useEnv,
+// поэтому опцию находят и внутри составных опций. Если после первого прохода
+// отметка установлена, окружение применяется к исходному cfg, а опции —
+// повторно поверх него.
+func applyOptions(cfg Config, opts []Option, getenv func(string) string) (Config, error) {
+	explicit := cfg
+	for _, o := range opts {
+		o(&explicit)
+	}
+	if !explicit.useEnv {
+		return explicit, nil
+	}
+	if err := applyEnv(&cfg, getenv); err != nil {
+		return cfg, fmt.Errorf("invalid environment configuration: %w", err)
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	// Ошибка разбора уже возвращена applyEnv.
+	_ = applySDKDisabled(&cfg, getenv)
+	return cfg, nil
+}
@@ -2,8 +2,11 @@ package logger
 
 import (
 	"context"
+	"reflect"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // contextKey используется для ключей контекста, чтобы избежать коллизий.
@@ -11,21 +14,100 @@ type contextKey string
 
 const (
 	traceIDKey contextKey = "trace_id"
+	spanIDKey  contextKey = "span_id"
 	userIDKey  contextKey = "user_id"
+	fieldsKey  contextKey = "fields"
 )
 
+// ContextWithTraceID возвращает контекст с trace_id для логирования.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// ContextWithUserID возвращает контекст с user_id для логирования.
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// ContextWithFields возвращает контекст с дополнительными полями.
+// Поля накапливаются при вложенных вызовах; поля родительского контекста не изменяются.
+func ContextWithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	prev, _ := ctx.Value(fieldsKey).([]zap.Field)
+	merged := make([]zap.Field, 0, len(prev)+len(fields))
+	merged = append(merged, prev...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsKey, merged)
+}
+
+// contextFieldKey — ключ служебного поля, через которое context.Context
+// доходит до core. Энкодеры пропускают поля типа SkipType.
+const contextFieldKey = "otelzap.context"
+
+// contextField упаковывает ctx в служебное поле.
+func contextField(ctx context.Context) zap.Field {
+	return zap.Field{Key: contextFieldKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+// contextFromFields извлекает ctx из служебного поля, если оно есть.
+func contextFromFields(fields []zapcore.Field) (context.Context, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		if f.Type != zapcore.SkipType || f.Key != contextFieldKey {
+			continue
+		}
+		if ctx, ok := f.Interface.(context.Context); ok && ctx != nil {
+			return ctx, true
+		}
+	}
+	return nil, false
+}
+
+// spanFieldMarker помечает поля trace_id/span_id, взятые из активного спана.
+// OTLP core их пропускает: идентификаторы уже есть в TraceId/SpanId записи.
+type spanFieldMarker struct{}
+
+// spanField создает строковое поле с идентификатором спана.
+func spanField(key contextKey, value string) zap.Field {
+	return zap.Field{Key: string(key), Type: zapcore.StringType, String: value, Interface: spanFieldMarker{}}
+}
+
+// isSpanField проверяет, создано ли поле через spanField.
+func isSpanField(f zapcore.Field) bool {
+	_, ok := f.Interface.(spanFieldMarker)
+	return ok && f.Type == zapcore.StringType
+}
+
 // fieldsFromContext извлекает поля из контекста.
 func (l *Logger) fieldsFromContext(ctx context.Context) []zap.Field {
 	var fields []zap.Field
 
 	// Стандартные поля
-	if traceID, ok := ctx.Value(traceIDKey).(string); ok && traceID != "" {
+	traceID, ok := ctx.Value(traceIDKey).(string)
+	if ok && traceID != "" {
 		fields = append(fields, zap.String(string(traceIDKey), traceID))
 	}
+	// Идентификаторы активного спана; явный trace_id из контекста имеет приоритет.
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		if traceID == "" {
+			fields = append(fields, spanField(traceIDKey, sc.TraceID().String()))
+		}
+		fields = append(fields, spanField(spanIDKey, sc.SpanID().String()))
+	}
 	if userID, ok := ctx.Value(userIDKey).(string); ok && userID != "" {
 		fields = append(fields, zap.String(string(userIDKey), userID))
 	}
 
+	// Зарегистрированные типизированные ключи
+	fields = appendRegisteredFields(ctx, fields)
+
+	// Поля, накопленные через ContextWithFields
+	if extra, ok := ctx.Value(fieldsKey).([]zap.Field); ok {
+		fields = append(fields, extra...)
+	}
+
 	// Кастомные extractors
 	for _, fn := range l.config.FieldExtractors {
 		fields = append(fields, fn(ctx)...)
@@ -33,3 +115,28 @@ func (l *Logger) fieldsFromContext(ctx context.Context) []zap.Field {
 
 	return fields
 }
+
+// contextFields собирает поля из контекста, поля вызова и служебное поле с ctx.
+// Поля, уже привязанные к логгеру через BindContext, пропускаются.
+func (l *Logger) contextFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	ctxFields := l.fieldsFromContext(ctx)
+	all := make([]zap.Field, 0, len(ctxFields)+len(fields)+1)
+	for _, f := range ctxFields {
+		if !l.isBound(f) {
+			all = append(all, f)
+		}
+	}
+	all = append(all, fields...)
+	return append(all, contextField(ctx))
+}
+
+// isBound проверяет, привязано ли поле к логгеру через BindContext.
+func (l *Logger) isBound(f zap.Field) bool {
+	for _, b := range l.bound {
+		// DeepEqual, а не Field.Equals: Interface может быть несравнимым (net.IP).
+		if b.Key == f.Key && reflect.DeepEqual(b, f) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// ContextKey — типизированный ключ контекста. Значения зарегистрированных
+// ключей автоматически добавляются в поля лога без отдельного FieldExtractor.
+type ContextKey[T any] struct {
+	name string
+}
+
+// contextFieldSource извлекает поле из контекста.
+type contextFieldSource interface {
+	fieldFromContext(ctx context.Context) (zap.Field, bool)
+}
+
+// contextKeyRegistry хранит зарегистрированные ключи.
+var contextKeyRegistry struct {
+	mu   sync.RWMutex
+	keys []contextFieldSource
+}
+
+// NewContextKey создает и регистрирует ключ с именем поля name.
+// Ключи обычно объявляются как переменные пакета.
+func NewContextKey[T any](name string) *ContextKey[T] {
+	k := &ContextKey[T]{name: name}
+	contextKeyRegistry.mu.Lock()
+	contextKeyRegistry.keys = append(contextKeyRegistry.keys, k)
+	contextKeyRegistry.mu.Unlock()
+	return k
+}
+
+// Name возвращает имя поля.
+func (k *ContextKey[T]) Name() string {
+	return k.name
+}
+
+// WithValue возвращает контекст со значением ключа.
+func (k *ContextKey[T]) WithValue(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// Value возвращает значение ключа из контекста.
+func (k *ContextKey[T]) Value(ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(k).(T)
+	return v, ok
+}
+
+// fieldFromContext реализует contextFieldSource.
+func (k *ContextKey[T]) fieldFromContext(ctx context.Context) (zap.Field, bool) {
+	v, ok := k.Value(ctx)
+	if !ok {
+		return zap.Field{}, false
+	}
+	return zap.Any(k.name, v), true
+}
+
+// appendRegisteredFields добавляет поля зарегистрированных ключей.
+func appendRegisteredFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	contextKeyRegistry.mu.RLock()
+	defer contextKeyRegistry.mu.RUnlock()
+	for _, k := range contextKeyRegistry.keys {
+		if f, ok := k.fieldFromContext(ctx); ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
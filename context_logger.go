@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// loggerKey — ключ контекста для логгера.
+const loggerKey contextKey = "logger"
+
+// defaultLogger — логгер, который FromContext возвращает, если в контексте его нет.
+var defaultLogger atomic.Pointer[Logger]
+
+// nopLogger возвращается FromContext, если логгер не найден и не задан по умолчанию.
+var nopLogger = NewNopLogger()
+
+// SetDefault задает логгер для FromContext, если в контексте логгера нет.
+// nil восстанавливает no-op логгер.
+func SetDefault(l *Logger) {
+	defaultLogger.Store(l)
+}
+
+// ToContext возвращает контекст с логгером l.
+func ToContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext возвращает логгер из контекста. Если его нет — логгер,
+// заданный через SetDefault, иначе no-op логгер.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerKey).(*Logger); ok && l != nil {
+		return l
+	}
+	if l := defaultLogger.Load(); l != nil {
+		return l
+	}
+	return nopLogger
+}
+
+// Функции уровня пакета пишут через логгер из контекста и вызывают zap
+// напрямую, чтобы caller указывал на вызывающий код.
+
+// Debug логирует сообщение на уровне Debug через логгер из ctx.
+func Debug(ctx context.Context, msg string, fields ...zap.Field) {
+	l := FromContext(ctx)
+	l.zapLogger.Debug(msg, l.contextFields(ctx, fields)...)
+}
+
+// Info логирует сообщение на уровне Info через логгер из ctx.
+func Info(ctx context.Context, msg string, fields ...zap.Field) {
+	l := FromContext(ctx)
+	l.zapLogger.Info(msg, l.contextFields(ctx, fields)...)
+}
+
+// Warn логирует сообщение на уровне Warn через логгер из ctx.
+func Warn(ctx context.Context, msg string, fields ...zap.Field) {
+	l := FromContext(ctx)
+	l.zapLogger.Warn(msg, l.contextFields(ctx, fields)...)
+}
+
+// Error логирует сообщение на уровне Error через логгер из ctx.
+func Error(ctx context.Context, msg string, fields ...zap.Field) {
+	l := FromContext(ctx)
+	l.zapLogger.Error(msg, l.contextFields(ctx, fields)...)
+}
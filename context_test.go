@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+var tenantKey = NewContextKey[string]("tenant")
+
+func TestContextHelpers(t *testing.T) {
+	l := NewNopLogger()
+	ctx := ContextWithTraceID(context.Background(), "12345")
+	ctx = ContextWithUserID(ctx, "user1")
+	ctx = tenantKey.WithValue(ctx, "acme")
+	ctx = ContextWithFields(ctx, zap.String("a", "1"))
+	parent := ctx
+	ctx = ContextWithFields(ctx, zap.Int("b", 2))
+
+	fields := l.fieldsFromContext(ctx)
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.Key)
+	}
+	expected := []string{"trace_id", "user_id", "tenant", "a", "b"}
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected fields %v, got %v", expected, keys)
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("Expected field %d to be %q, got %q", i, expected[i], keys[i])
+		}
+	}
+	if got := len(l.fieldsFromContext(parent)); got != 4 {
+		t.Errorf("Expected parent context to keep 4 fields, got %d", got)
+	}
+	if v, ok := tenantKey.Value(ctx); !ok || v != "acme" {
+		t.Errorf("Expected tenant=acme, got %q", v)
+	}
+}
+
+func TestLoggerInContext(t *testing.T) {
+	rec := NewRecordingLogger()
+	ctx := ToContext(context.Background(), rec.WithFields(zap.String("job", "import")))
+	ctx = ContextWithTraceID(ctx, "12345")
+
+	Info(ctx, "package level")
+	FromContext(ctx).Warn(ctx, "from context")
+
+	entries := rec.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	for _, e := range entries {
+		fields := e.ContextMap()
+		if fields["job"] != "import" || fields["trace_id"] != "12345" {
+			t.Errorf("Expected job and trace_id fields, got %v", fields)
+		}
+		if file := filepath.Base(e.Caller.File); file != "context_test.go" {
+			t.Errorf("Expected caller in context_test.go, got %s", e.Caller.File)
+		}
+	}
+}
+
+func TestFromContextDefault(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+
+	if l := FromContext(context.Background()); l == nil {
+		t.Fatal("Expected no-op logger without default")
+	}
+	Error(context.Background(), "dropped")
+
+	rec := NewRecordingLogger()
+	SetDefault(rec.Logger)
+	Error(context.Background(), "default")
+	if got := rec.Logs().FilterMessage("default").Len(); got != 1 {
+		t.Errorf("Expected entry in default logger, got %d", got)
+	}
+}
@@ -0,0 +1,239 @@
+package logger
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Переменные окружения, которые учитывает WithEnvConfig.
+const (
+	envSDKDisabled          = "OTEL_SDK_DISABLED"
+	envLogsExporter         = "OTEL_LOGS_EXPORTER"
+	envServiceName          = "OTEL_SERVICE_NAME"
+	envResourceAttributes   = "OTEL_RESOURCE_ATTRIBUTES"
+	envOTLPPrefix           = "OTEL_EXPORTER_OTLP_"
+	envOTLPLogsPrefix       = "OTEL_EXPORTER_OTLP_LOGS_"
+	envOTLPEndpoint         = "ENDPOINT"
+	envOTLPProtocol         = "PROTOCOL"
+	envOTLPHeaders          = "HEADERS"
+	envOTLPCompression      = "COMPRESSION"
+	envOTLPTimeout          = "TIMEOUT"
+	envOTLPInsecure         = "INSECURE"
+	envOTLPCertificate      = "CERTIFICATE"
+	envOTLPClientCert       = "CLIENT_CERTIFICATE"
+	envOTLPClientKey        = "CLIENT_KEY"
+	envOtelzapLevel         = "OTELZAP_LEVEL"
+	envOtelzapFormat        = "OTELZAP_FORMAT"
+	defaultOTLPHTTPLogsPath = "/v1/logs"
+)
+
+// applyEnv заполняет cfg из переменных окружения.
+// Переменные OTEL_EXPORTER_OTLP_LOGS_* имеют приоритет над OTEL_EXPORTER_OTLP_*.
+func applyEnv(cfg *Config, getenv func(string) string) error {
+	if v := getenv(envOtelzapLevel); v != "" {
+		cfg.Level = v
+	}
+	if v := getenv(envOtelzapFormat); v != "" {
+		switch strings.ToLower(v) {
+		case "json":
+			cfg.AsJSON = true
+		case "console", "text":
+			cfg.AsJSON = false
+		default:
+			return fmt.Errorf("%s: unknown format: %s", envOtelzapFormat, v)
+		}
+	}
+
+	if v := getenv(envLogsExporter); v != "" {
+		switch strings.ToLower(v) {
+		case "otlp":
+			cfg.EnableOTLP = true
+		case "none":
+			cfg.EnableOTLP = false
+		default:
+			return fmt.Errorf("%s: unsupported exporter: %s", envLogsExporter, v)
+		}
+	}
+	if err := applySDKDisabled(cfg, getenv); err != nil {
+		return err
+	}
+
+	if err := applyResourceEnv(cfg, getenv); err != nil {
+		return err
+	}
+	return applyOTLPEnv(cfg, getenv)
+}
+
+// applySDKDisabled отключает OTLP при OTEL_SDK_DISABLED=true.
+// Вызывается и после явных опций: переменная имеет приоритет над WithEnableOTLP.
+func applySDKDisabled(cfg *Config, getenv func(string) string) error {
+	v := getenv(envSDKDisabled)
+	if v == "" {
+		return nil
+	}
+	disabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", envSDKDisabled, err)
+	}
+	if disabled {
+		cfg.EnableOTLP = false
+	}
+	return nil
+}
+
+// applyResourceEnv применяет OTEL_RESOURCE_ATTRIBUTES и OTEL_SERVICE_NAME.
+func applyResourceEnv(cfg *Config, getenv func(string) string) error {
+	if v := getenv(envResourceAttributes); v != "" {
+		pairs, err := parseKeyValueList(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", envResourceAttributes, err)
+		}
+		for _, kv := range pairs {
+			switch kv[0] {
+			case "service.name":
+				cfg.ServiceName = kv[1]
+			case "service.version":
+				cfg.ServiceVersion = kv[1]
+			case "deployment.environment", "deployment.environment.name":
+				cfg.ServiceEnvironment = kv[1]
+			default:
+				cfg.ResourceAttributes = append(cfg.ResourceAttributes, attribute.String(kv[0], kv[1]))
+			}
+		}
+	}
+	// OTEL_SERVICE_NAME имеет приоритет над service.name из OTEL_RESOURCE_ATTRIBUTES.
+	if v := getenv(envServiceName); v != "" {
+		cfg.ServiceName = v
+	}
+	return nil
+}
+
+// applyOTLPEnv применяет переменные OTEL_EXPORTER_OTLP_*.
+func applyOTLPEnv(cfg *Config, getenv func(string) string) error {
+	// lookup возвращает значение и имя переменной, из которой оно взято.
+	lookup := func(name string) (string, string) {
+		if v := getenv(envOTLPLogsPrefix + name); v != "" {
+			return v, envOTLPLogsPrefix + name
+		}
+		return getenv(envOTLPPrefix + name), envOTLPPrefix + name
+	}
+
+	if v, name := lookup(envOTLPProtocol); v != "" {
+		switch v {
+		case OTLPProtocolGRPC, OTLPProtocolHTTPProtobuf:
+			cfg.OtlpProtocol = v
+		default:
+			return fmt.Errorf("%s: unsupported protocol: %s", name, v)
+		}
+	}
+
+	if v, name := lookup(envOTLPEndpoint); v != "" {
+		signal := strings.HasPrefix(name, envOTLPLogsPrefix)
+		if err := applyEndpointEnv(cfg, v, signal); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if v, name := lookup(envOTLPInsecure); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		cfg.OtlpUseTLS = !insecure
+	}
+
+	if v, name := lookup(envOTLPHeaders); v != "" {
+		pairs, err := parseKeyValueList(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		cfg.OtlpExporter.Headers = make(map[string]string, len(pairs))
+		for _, kv := range pairs {
+			cfg.OtlpExporter.Headers[kv[0]] = kv[1]
+		}
+	}
+	if v, _ := lookup(envOTLPCompression); v != "" {
+		cfg.OtlpExporter.Compression = v
+	}
+	if v, name := lookup(envOTLPTimeout); v != "" {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			return fmt.Errorf("%s: invalid timeout in milliseconds: %s", name, v)
+		}
+		cfg.OtlpExporter.Timeout = time.Duration(ms) * time.Millisecond
+	}
+	if v, _ := lookup(envOTLPCertificate); v != "" {
+		cfg.OtlpExporter.CAFile = v
+	}
+	if v, _ := lookup(envOTLPClientCert); v != "" {
+		cfg.OtlpExporter.CertFile = v
+	}
+	if v, _ := lookup(envOTLPClientKey); v != "" {
+		cfg.OtlpExporter.KeyFile = v
+	}
+	return nil
+}
+
+// applyEndpointEnv разбирает URL эндпоинта. Схема определяет TLS, путь —
+// URL для http/protobuf: для OTEL_EXPORTER_OTLP_LOGS_ENDPOINT он используется
+// как есть, для общего эндпоинта к нему добавляется /v1/logs.
+func applyEndpointEnv(cfg *Config, raw string, signal bool) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Host == "" {
+		// Значение без схемы, например collector:4317.
+		cfg.OtlpEndpoint = raw
+		return nil
+	}
+
+	switch u.Scheme {
+	case "http":
+		cfg.OtlpUseTLS = false
+	case "https":
+		cfg.OtlpUseTLS = true
+	default:
+		return fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	cfg.OtlpEndpoint = u.Host
+
+	// Endpoint сигнала используется как есть: пустой путь означает "/".
+	path := strings.TrimSuffix(u.Path, "/")
+	switch {
+	case signal && u.Path == "":
+		cfg.OtlpExporter.URLPath = "/"
+	case signal:
+		cfg.OtlpExporter.URLPath = u.Path
+	case path != "":
+		cfg.OtlpExporter.URLPath = path + defaultOTLPHTTPLogsPath
+	}
+	return nil
+}
+
+// parseKeyValueList разбирает список вида key1=value1,key2=value2.
+// Значения декодируются как URL-encoded строки.
+func parseKeyValueList(s string) ([][2]string, error) {
+	var pairs [][2]string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid key-value pair: %q", item)
+		}
+		decoded, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", key, err)
+		}
+		pairs = append(pairs, [2]string{key, decoded})
+	}
+	return pairs, nil
+}
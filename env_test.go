@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fakeEnv возвращает getenv поверх map.
+func fakeEnv(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestApplyOptionsEnv(t *testing.T) {
+	env := fakeEnv(map[string]string{
+		"OTEL_LOGS_EXPORTER":              "otlp",
+		"OTEL_SERVICE_NAME":               "env-service",
+		"OTEL_RESOURCE_ATTRIBUTES":        "deployment.environment=staging,team=core%20platform",
+		"OTEL_EXPORTER_OTLP_ENDPOINT":     "https://collector:4318/base",
+		"OTEL_EXPORTER_OTLP_PROTOCOL":     "http/protobuf",
+		"OTEL_EXPORTER_OTLP_HEADERS":      "authorization=generic",
+		"OTEL_EXPORTER_OTLP_LOGS_HEADERS": "authorization=Bearer%20token,x-tenant=acme",
+		"OTEL_EXPORTER_OTLP_LOGS_TIMEOUT": "1500",
+		"OTEL_EXPORTER_OTLP_COMPRESSION":  "gzip",
+		"OTEL_EXPORTER_OTLP_CERTIFICATE":  "/etc/ca.pem",
+		"OTELZAP_LEVEL":                   "debug",
+		"OTELZAP_FORMAT":                  "console",
+	})
+
+	cfg, err := applyOptions(Config{AsJSON: true, Level: "info"}, []Option{
+		WithEnvConfig(),
+		WithServiceName("explicit-service"),
+		WithOTLPHeaders(map[string]string{"x-tenant": "explicit"}),
+	}, env)
+	if err != nil {
+		t.Fatalf("Failed to apply options: %v", err)
+	}
+
+	if cfg.ServiceName != "explicit-service" {
+		t.Errorf("Expected explicit service name, got %q", cfg.ServiceName)
+	}
+	if cfg.ServiceEnvironment != "staging" {
+		t.Errorf("Expected environment=staging, got %q", cfg.ServiceEnvironment)
+	}
+	if len(cfg.ResourceAttributes) != 1 || cfg.ResourceAttributes[0].Value.AsString() != "core platform" {
+		t.Errorf("Expected team=core platform resource attribute, got %v", cfg.ResourceAttributes)
+	}
+	if !cfg.EnableOTLP || cfg.AsJSON || cfg.Level != "debug" {
+		t.Errorf("Expected OTLP enabled, console format and debug level, got %+v", cfg)
+	}
+	if cfg.OtlpEndpoint != "collector:4318" || !cfg.OtlpUseTLS {
+		t.Errorf("Expected TLS endpoint collector:4318, got %q (tls=%v)", cfg.OtlpEndpoint, cfg.OtlpUseTLS)
+	}
+	if cfg.OtlpExporter.URLPath != "/base/v1/logs" {
+		t.Errorf("Expected URL path /base/v1/logs, got %q", cfg.OtlpExporter.URLPath)
+	}
+	if cfg.OtlpProtocol != OTLPProtocolHTTPProtobuf {
+		t.Errorf("Expected protocol http/protobuf, got %q", cfg.OtlpProtocol)
+	}
+	if got := cfg.OtlpExporter.Headers["authorization"]; got != "Bearer token" {
+		t.Errorf("Expected logs-specific authorization header, got %q", got)
+	}
+	if got := cfg.OtlpExporter.Headers["x-tenant"]; got != "explicit" {
+		t.Errorf("Expected explicit x-tenant header, got %q", got)
+	}
+	if cfg.OtlpExporter.Timeout != 1500*time.Millisecond {
+		t.Errorf("Expected timeout 1.5s, got %v", cfg.OtlpExporter.Timeout)
+	}
+	if cfg.OtlpExporter.Compression != "gzip" || cfg.OtlpExporter.CAFile != "/etc/ca.pem" {
+		t.Errorf("Expected gzip compression and CA file, got %+v", cfg.OtlpExporter)
+	}
+}
+
+func TestApplyOptionsWithoutEnv(t *testing.T) {
+	env := fakeEnv(map[string]string{"OTELZAP_LEVEL": "debug"})
+	cfg, err := applyOptions(Config{Level: "info"}, nil, env)
+	if err != nil {
+		t.Fatalf("Failed to apply options: %v", err)
+	}
+	if cfg.Level != "info" {
+		t.Errorf("Expected environment to be ignored without WithEnvConfig, got level %q", cfg.Level)
+	}
+}
+
+func TestApplyEnvLogsEndpoint(t *testing.T) {
+	cfg := Config{}
+	err := applyEnv(&cfg, fakeEnv(map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT":      "https://ignored:4317",
+		"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT": "http://collector:4318/custom/logs",
+	}))
+	if err != nil {
+		t.Fatalf("Failed to apply env: %v", err)
+	}
+	if cfg.OtlpEndpoint != "collector:4318" || cfg.OtlpUseTLS {
+		t.Errorf("Expected insecure endpoint collector:4318, got %q (tls=%v)", cfg.OtlpEndpoint, cfg.OtlpUseTLS)
+	}
+	if cfg.OtlpExporter.URLPath != "/custom/logs" {
+		t.Errorf("Expected URL path /custom/logs, got %q", cfg.OtlpExporter.URLPath)
+	}
+}
+
+func TestApplyEnvLogsEndpointEmptyPath(t *testing.T) {
+	cfg := Config{}
+	err := applyEnv(&cfg, fakeEnv(map[string]string{
+		"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT": "https://collector:4318",
+	}))
+	if err != nil {
+		t.Fatalf("Failed to apply env: %v", err)
+	}
+	if cfg.OtlpExporter.URLPath != "/" {
+		t.Errorf("Expected signal endpoint without path to use /, got %q", cfg.OtlpExporter.URLPath)
+	}
+}
+
+func TestApplyEnvErrors(t *testing.T) {
+	tests := map[string]string{
+		"OTELZAP_FORMAT":                   "xml",
+		"OTEL_LOGS_EXPORTER":               "kafka",
+		"OTEL_SDK_DISABLED":                "maybe",
+		"OTEL_EXPORTER_OTLP_PROTOCOL":      "http/json",
+		"OTEL_EXPORTER_OTLP_TIMEOUT":       "soon",
+		"OTEL_EXPORTER_OTLP_HEADERS":       "broken",
+		"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT": "ftp://collector",
+	}
+	for key, value := range tests {
+		t.Run(key, func(t *testing.T) {
+			cfg := Config{}
+			if err := applyEnv(&cfg, fakeEnv(map[string]string{key: value})); err == nil {
+				t.Errorf("Expected error for %s=%s", key, value)
+			}
+		})
+	}
+}
+
+func TestApplyOptionsDoesNotDuplicate(t *testing.T) {
+	extractor := func(context.Context) []zap.Field { return nil }
+	cfg, err := applyOptions(Config{}, []Option{WithFieldExtractor(extractor), WithEnvConfig()}, fakeEnv(nil))
+	if err != nil {
+		t.Fatalf("Failed to apply options: %v", err)
+	}
+	if len(cfg.FieldExtractors) != 1 {
+		t.Errorf("Expected 1 field extractor, got %d", len(cfg.FieldExtractors))
+	}
+}
+
+func TestApplyOptionsComposedEnvConfig(t *testing.T) {
+	defaults := func(c *Config) {
+		WithEnvConfig()(c)
+		WithServiceName("composed")(c)
+	}
+	env := fakeEnv(map[string]string{"OTELZAP_LEVEL": "debug", "OTEL_SERVICE_NAME": "env-service"})
+	cfg, err := applyOptions(Config{Level: "info"}, []Option{defaults}, env)
+	if err != nil {
+		t.Fatalf("Failed to apply options: %v", err)
+	}
+	if cfg.Level != "debug" || cfg.ServiceName != "composed" {
+		t.Errorf("Expected env level and explicit service name, got level %q, service %q", cfg.Level, cfg.ServiceName)
+	}
+}
+
+func TestSDKDisabledOverridesOptions(t *testing.T) {
+	env := fakeEnv(map[string]string{"OTEL_SDK_DISABLED": "true"})
+	cfg, err := applyOptions(Config{}, []Option{WithEnvConfig(), WithEnableOTLP(true)}, env)
+	if err != nil {
+		t.Fatalf("Failed to apply options: %v", err)
+	}
+	if cfg.EnableOTLP {
+		t.Error("Expected OTEL_SDK_DISABLED to disable OTLP despite WithEnableOTLP")
+	}
+}
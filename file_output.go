@@ -0,0 +1,420 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Значения по умолчанию для буфера записи в файл.
+const (
+	defaultFileBufferSize    = 256 * 1024
+	defaultFileFlushInterval = 30 * time.Second
+	backupTimeFormat         = "2006-01-02T15-04-05.000"
+	compressSuffix           = ".gz"
+)
+
+// RotationConfig — настройки ротации и хранения файла логов.
+type RotationConfig struct {
+	MaxSize        int64         // Максимальный размер файла в байтах (0 — без ограничения).
+	Interval       time.Duration // Ротация по времени (0 — отключена).
+	MaxAge         time.Duration // Удалять архивы старше MaxAge (0 — не удалять по возрасту).
+	MaxBackups     int           // Сколько архивов хранить (0 — все).
+	Compress       bool          // Сжимать архивы gzip.
+	RotateOnSIGHUP bool          // Переоткрывать файл по SIGHUP после внешней ротации (logrotate).
+	BufferSize     int           // Размер буфера записи (0 — 256 КБ, но не больше MaxSize).
+	FlushInterval  time.Duration // Период сброса буфера (0 — 30 секунд).
+}
+
+// fileOutput — буферизованная запись в ротируемый файл.
+type fileOutput struct {
+	ws   *zapcore.BufferedWriteSyncer
+	file *rotatingFile
+}
+
+// newFileOutput открывает файл и создает буфер записи.
+func newFileOutput(path string, cfg RotationConfig) (*fileOutput, error) {
+	file, err := newRotatingFile(path, cfg, time.Now)
+	if err != nil {
+		return nil, err
+	}
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = defaultFileBufferSize
+		if cfg.MaxSize > 0 && cfg.MaxSize < int64(size) {
+			size = int(cfg.MaxSize)
+		}
+	}
+	interval := cfg.FlushInterval
+	if interval <= 0 {
+		interval = defaultFileFlushInterval
+	}
+	out := &fileOutput{
+		ws:   &zapcore.BufferedWriteSyncer{WS: file, Size: size, FlushInterval: interval},
+		file: file,
+	}
+	if cfg.RotateOnSIGHUP {
+		file.notifySIGHUP(out.reopen)
+	}
+	return out, nil
+}
+
+// reopen сбрасывает буфер в текущий файл и открывает файл по исходному пути.
+func (o *fileOutput) reopen() error {
+	return errors.Join(o.ws.Sync(), o.file.Reopen())
+}
+
+// close сбрасывает буфер и закрывает файл.
+func (o *fileOutput) close() error {
+	if o == nil {
+		return nil
+	}
+	return errors.Join(o.ws.Stop(), o.file.Close())
+}
+
+// createFileCore создает core для записи в файл.
+func createFileCore(asJSON bool, ws zapcore.WriteSyncer, level zapcore.LevelEnabler) zapcore.Core {
+	return zapcore.NewCore(newEncoder(asJSON), ws, level)
+}
+
+// rotatingFile — файл с ротацией по размеру и времени и переоткрытием по сигналу.
+// Безопасен для конкурентного использования.
+type rotatingFile struct {
+	path string
+	cfg  RotationConfig
+	now  func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	millMu sync.Mutex     // Сериализует сжатие и удаление архивов.
+	mill   sync.WaitGroup // Фоновые сжатие и удаление архивов.
+
+	signals chan os.Signal
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// newRotatingFile открывает файл для дозаписи, создавая каталог при необходимости.
+func newRotatingFile(path string, cfg RotationConfig, now func() time.Time) (*rotatingFile, error) {
+	if cfg.MaxSize < 0 || cfg.Interval < 0 || cfg.MaxAge < 0 || cfg.MaxBackups < 0 {
+		return nil, fmt.Errorf("rotation limits must not be negative")
+	}
+	f := &rotatingFile{path: path, cfg: cfg, now: now}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open открывает файл по пути f.path. Вызывается под f.mu.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+// Write реализует io.Writer; ротирует файл перед записью при достижении лимитов.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// shouldRotate проверяет лимиты размера и времени. Пустой файл не ротируется.
+func (f *rotatingFile) shouldRotate(n int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.cfg.MaxSize > 0 && f.size+int64(n) > f.cfg.MaxSize {
+		return true
+	}
+	return f.cfg.Interval > 0 && !f.now().Before(f.openedAt.Add(f.cfg.Interval))
+}
+
+// Sync реализует zapcore.WriteSyncer.
+func (f *rotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+// Rotate переименовывает текущий файл в архив и открывает новый.
+func (f *rotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	if f.size == 0 {
+		return nil
+	}
+	return f.rotate()
+}
+
+// Reopen закрывает текущий файл и открывает файл по исходному пути без
+// переименования. Используется, когда файл уже перемещен внешней ротацией.
+func (f *rotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	f.file = nil
+	return f.open()
+}
+
+// rotate выполняет ротацию. Вызывается под f.mu.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	f.file = nil
+	now := f.now()
+	backup := f.backupName(now)
+	if err := os.Rename(f.path, backup); err != nil {
+		// Продолжаем писать в прежний файл.
+		return errors.Join(fmt.Errorf("failed to rename log file: %w", err), f.open())
+	}
+	// Время изменения архива задает порядок и возраст при удалении.
+	_ = os.Chtimes(backup, now, now)
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.mill.Add(1)
+	go func() {
+		defer f.mill.Done()
+		f.millMu.Lock()
+		defer f.millMu.Unlock()
+		if f.cfg.Compress {
+			if err := compressFile(backup); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to compress log file: %v\n", err)
+			}
+		}
+		if err := f.removeOld(now); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove old log files: %v\n", err)
+		}
+	}()
+	return nil
+}
+
+// backupName возвращает свободное имя архива вида name-<время>.ext.
+func (f *rotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := f.nameParts()
+	base := filepath.Join(dir, prefix+t.UTC().Format(backupTimeFormat))
+	name := base + ext
+	for i := 1; exists(name) || exists(name+compressSuffix); i++ {
+		name = base + "." + strconv.Itoa(i) + ext
+	}
+	return name
+}
+
+// nameParts разбивает путь на каталог, префикс архивов и расширение.
+func (f *rotatingFile) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(f.path)
+	base := filepath.Base(f.path)
+	ext = filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// backups возвращает архивы, начиная с самого нового.
+func (f *rotatingFile) backups() ([]os.FileInfo, error) {
+	dir, prefix, ext := f.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var infos []os.FileInfo
+	for _, e := range entries {
+		if e.IsDir() || !isBackupName(e.Name(), prefix, ext) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue // Файл удален конкурентно.
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if !infos[i].ModTime().Equal(infos[j].ModTime()) {
+			return infos[i].ModTime().After(infos[j].ModTime())
+		}
+		return infos[i].Name() > infos[j].Name()
+	})
+	return infos, nil
+}
+
+// isBackupName проверяет, что имя создано backupName:
+// prefix<время>[.N]ext с необязательным суффиксом сжатия.
+func isBackupName(name, prefix, ext string) bool {
+	name = strings.TrimSuffix(name, compressSuffix)
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) ||
+		len(name) < len(prefix)+len(backupTimeFormat)+len(ext) {
+		return false
+	}
+	middle := name[len(prefix) : len(name)-len(ext)]
+	stamp, suffix := middle[:len(backupTimeFormat)], middle[len(backupTimeFormat):]
+	if _, err := time.Parse(backupTimeFormat, stamp); err != nil {
+		return false
+	}
+	if suffix == "" {
+		return true
+	}
+	n, ok := strings.CutPrefix(suffix, ".")
+	if !ok || n == "" {
+		return false
+	}
+	for _, c := range n {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// removeOld удаляет архивы сверх MaxBackups и старше MaxAge.
+func (f *rotatingFile) removeOld(now time.Time) error {
+	if f.cfg.MaxBackups == 0 && f.cfg.MaxAge == 0 {
+		return nil
+	}
+	infos, err := f.backups()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(f.path)
+	var errs []error
+	for i, info := range infos {
+		tooMany := f.cfg.MaxBackups > 0 && i >= f.cfg.MaxBackups
+		tooOld := f.cfg.MaxAge > 0 && now.Sub(info.ModTime()) > f.cfg.MaxAge
+		if tooMany || tooOld {
+			if err := os.Remove(filepath.Join(dir, info.Name())); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifySIGHUP вызывает reopen по сигналу SIGHUP до вызова Close.
+// Ротация самим логгером выполняется только по размеру и времени.
+func (f *rotatingFile) notifySIGHUP(reopen func() error) {
+	f.signals = make(chan os.Signal, 1)
+	f.stop = make(chan struct{})
+	f.done = make(chan struct{})
+	signal.Notify(f.signals, syscall.SIGHUP)
+	go func() {
+		defer close(f.done)
+		for {
+			select {
+			case <-f.signals:
+				if err := reopen(); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to reopen log file: %v\n", err)
+				}
+			case <-f.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close останавливает обработку сигналов, закрывает файл и дожидается
+// фонового сжатия архивов.
+func (f *rotatingFile) Close() error {
+	if f.signals != nil {
+		signal.Stop(f.signals)
+		close(f.stop)
+		<-f.done
+		f.signals = nil
+	}
+	f.mu.Lock()
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.mu.Unlock()
+	f.mill.Wait()
+	return err
+}
+
+// compressFile сжимает файл в name.gz, сохраняя время изменения, и удаляет исходный.
+func compressFile(name string) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := name + compressSuffix + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	err = errors.Join(err, gz.Close(), dst.Close())
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, name+compressSuffix); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	_ = os.Chtimes(name+compressSuffix, info.ModTime(), info.ModTime())
+	return os.Remove(name)
+}
+
+// exists проверяет, существует ли файл.
+func exists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
@@ -0,0 +1,307 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock — управляемое время для тестов ротации.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func newTestRotatingFile(t *testing.T, cfg RotationConfig, clock *fakeClock) (*rotatingFile, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := newRotatingFile(path, cfg, clock.now)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	return f, path
+}
+
+// backupNames возвращает имена архивов рядом с path.
+func backupNames(t *testing.T, path string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Name() != filepath.Base(path) {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// readLog возвращает содержимое файла, распаковывая gzip.
+func readLog(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.HasSuffix(name, compressSuffix) {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data, err = io.ReadAll(gz); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return string(data)
+}
+
+func TestRotateBySize(t *testing.T) {
+	clock := newFakeClock()
+	f, path := newTestRotatingFile(t, RotationConfig{MaxSize: 100, MaxBackups: 2}, clock)
+
+	line := strings.Repeat("x", 29) + "\n"
+	for i := 0; i < 10; i++ {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		clock.advance(time.Second)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(readLog(t, path)); got != 30 {
+		t.Errorf("Expected 30 bytes in current file, got %d", got)
+	}
+	backups := backupNames(t, path)
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups, got %v", backups)
+	}
+	for _, name := range backups {
+		if got := len(readLog(t, filepath.Join(filepath.Dir(path), name))); got != 90 {
+			t.Errorf("Expected 90 bytes in %s, got %d", name, got)
+		}
+	}
+}
+
+func TestRotateByTime(t *testing.T) {
+	clock := newFakeClock()
+	f, path := newTestRotatingFile(t, RotationConfig{Interval: time.Hour}, clock)
+
+	_, _ = f.Write([]byte("first\n"))
+	clock.advance(30 * time.Minute)
+	_, _ = f.Write([]byte("second\n"))
+	clock.advance(31 * time.Minute)
+	_, _ = f.Write([]byte("third\n"))
+	_ = f.Close()
+
+	backups := backupNames(t, path)
+	if len(backups) != 1 {
+		t.Fatalf("Expected 1 backup, got %v", backups)
+	}
+	if want := "app-2024-01-01T01-01-00.000.log"; backups[0] != want {
+		t.Errorf("Expected backup %s, got %s", want, backups[0])
+	}
+	if got := readLog(t, filepath.Join(filepath.Dir(path), backups[0])); got != "first\nsecond\n" {
+		t.Errorf("Unexpected backup content: %q", got)
+	}
+	if got := readLog(t, path); got != "third\n" {
+		t.Errorf("Unexpected current content: %q", got)
+	}
+}
+
+func TestRotateMaxAge(t *testing.T) {
+	clock := newFakeClock()
+	f, path := newTestRotatingFile(t, RotationConfig{MaxAge: time.Hour}, clock)
+
+	for i := 0; i < 4; i++ {
+		_, _ = f.Write([]byte(fmt.Sprintf("line %d\n", i)))
+		if err := f.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+		clock.advance(40 * time.Minute)
+	}
+	_, _ = f.Write([]byte("last\n"))
+	_ = f.Rotate()
+	_ = f.Close()
+
+	// Архивы созданы в 0:00, 0:40, 1:20, 2:00 и 2:40; старше часа — первые три.
+	backups := backupNames(t, path)
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups within max age, got %v", backups)
+	}
+	if got := readLog(t, filepath.Join(filepath.Dir(path), backups[0])); got != "line 3\n" {
+		t.Errorf("Unexpected oldest kept backup: %q", got)
+	}
+}
+
+func TestRotateKeepsForeignFiles(t *testing.T) {
+	clock := newFakeClock()
+	f, path := newTestRotatingFile(t, RotationConfig{MaxBackups: 1, MaxAge: time.Hour}, clock)
+	dir := filepath.Dir(path)
+
+	old := clock.now().Add(-24 * time.Hour)
+	foreign := []string{"app-audit.log", "app-2.log", "app-2024-01-01T00-00-00.000.x.log", "app-audit.log.gz"}
+	for _, name := range foreign {
+		name = filepath.Join(dir, name)
+		if err := os.WriteFile(name, []byte("foreign\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_ = os.Chtimes(name, old, old)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, _ = f.Write([]byte(fmt.Sprintf("line %d\n", i)))
+		if err := f.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = f.Close()
+
+	for _, name := range foreign {
+		if !exists(filepath.Join(dir, name)) {
+			t.Errorf("Expected foreign file %s to survive retention", name)
+		}
+	}
+	if got := len(backupNames(t, path)) - len(foreign); got != 1 {
+		t.Errorf("Expected 1 own backup, got %d: %v", got, backupNames(t, path))
+	}
+}
+
+func TestIsBackupName(t *testing.T) {
+	tests := map[string]bool{
+		"app-2024-01-01T00-00-00.000.log":       true,
+		"app-2024-01-01T00-00-00.000.2.log":     true,
+		"app-2024-01-01T00-00-00.000.12.log.gz": true,
+		"app-audit.log":                         false,
+		"app-2.log":                             false,
+		"app-2024-01-01T00-00-00.000..log":      false,
+		"app-2024-01-01T00-00-00.000.x.log":     false,
+		"app-2024-01-01T00-00-00.000.log.bak":   false,
+		"other-2024-01-01T00-00-00.000.log":     false,
+	}
+	for name, want := range tests {
+		if got := isBackupName(name, "app-", ".log"); got != want {
+			t.Errorf("isBackupName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestRotateCompress(t *testing.T) {
+	clock := newFakeClock()
+	f, path := newTestRotatingFile(t, RotationConfig{Compress: true}, clock)
+
+	_, _ = f.Write([]byte("compressed\n"))
+	if err := f.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	// Повторная ротация в тот же момент не перезаписывает архив.
+	_, _ = f.Write([]byte("again\n"))
+	if err := f.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	backups := backupNames(t, path)
+	if len(backups) != 2 {
+		t.Fatalf("Expected 2 backups, got %v", backups)
+	}
+	var contents []string
+	for _, name := range backups {
+		if !strings.HasSuffix(name, ".log.gz") {
+			t.Errorf("Expected gzip backup, got %s", name)
+		}
+		contents = append(contents, readLog(t, filepath.Join(filepath.Dir(path), name)))
+	}
+	sort.Strings(contents)
+	if got := strings.Join(contents, ""); got != "again\ncompressed\n" {
+		t.Errorf("Unexpected backup content: %q", got)
+	}
+}
+
+func TestRotateConcurrent(t *testing.T) {
+	clock := newFakeClock()
+	f, path := newTestRotatingFile(t, RotationConfig{MaxSize: 512}, clock)
+
+	const writers, lines = 8, 200
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < lines; i++ {
+				if _, err := fmt.Fprintf(f, "writer %d line %03d\n", w, i); err != nil {
+					t.Error(err)
+					return
+				}
+				if i%50 == 0 {
+					_ = f.Rotate()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	_ = f.Close()
+
+	total := strings.Count(readLog(t, path), "\n")
+	for _, name := range backupNames(t, path) {
+		content := readLog(t, filepath.Join(filepath.Dir(path), name))
+		if len(content) > 512 {
+			t.Errorf("Backup %s exceeds max size: %d", name, len(content))
+		}
+		total += strings.Count(content, "\n")
+	}
+	if total != writers*lines {
+		t.Errorf("Expected %d lines, got %d", writers*lines, total)
+	}
+}
+
+func TestFileOutputSync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	l, err := NewLogger(context.Background(),
+		WithEnableStdout(false),
+		WithFileOutput(path, RotationConfig{FlushInterval: time.Hour}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer l.Close()
+
+	l.Info(context.Background(), "buffered")
+	if got := readLog(t, path); got != "" {
+		t.Errorf("Expected entry to stay in buffer, got %q", got)
+	}
+	if err := l.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := readLog(t, path); !strings.Contains(got, `"message":"buffered"`) {
+		t.Errorf("Expected entry after Sync, got %q", got)
+	}
+}
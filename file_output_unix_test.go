@@ -0,0 +1,55 @@
+//go:build unix
+
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestFileOutputSIGHUP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	l, err := NewLogger(context.Background(),
+		WithEnableStdout(false),
+		WithFileOutput(path, RotationConfig{RotateOnSIGHUP: true}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	// Запись остается в буфере; logrotate перемещает файл и шлет HUP.
+	l.Info(context.Background(), "before")
+	moved := path + ".1"
+	if err := os.Rename(path, moved); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !exists(path) {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for reopen")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	l.Info(context.Background(), "after")
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if got := readLog(t, moved); !strings.Contains(got, "before") || strings.Contains(got, "after") {
+		t.Errorf("Expected buffered entry flushed to moved file, got %q", got)
+	}
+	if got := readLog(t, path); !strings.Contains(got, "after") || strings.Contains(got, "before") {
+		t.Errorf("Expected entry after HUP in new file, got %q", got)
+	}
+	if names := backupNames(t, path); len(names) != 1 || names[0] != filepath.Base(moved) {
+		t.Errorf("Expected no backups created by HUP, got %v", names)
+	}
+}
@@ -0,0 +1,365 @@
+// Package grpclog содержит gRPC интерцепторы для сервера и клиента,
+// которые создают логгер вызова и пишут запись о завершении вызова.
+package grpclog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/major1ink/otelzap"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// payloadKey — поле с содержимым сообщения.
+const payloadKey = "rpc.message.payload"
+
+// LevelDecider определяет уровень записи по коду статуса.
+type LevelDecider func(code codes.Code) zapcore.Level
+
+// PayloadRedactor возвращает значение сообщения для лога, например копию
+// без секретных полей. Если возвращен nil, сообщение не логируется.
+type PayloadRedactor func(fullMethod string, msg any) any
+
+// Config — конфигурация интерцепторов.
+type Config struct {
+	LevelDecider    LevelDecider    // Уровень записи о завершении вызова.
+	LogPayloads     bool            // Логировать сообщения запросов и ответов на уровне Debug.
+	PayloadRedactor PayloadRedactor // Обработка сообщений перед логированием.
+}
+
+// Option — функция для настройки Config.
+type Option func(c *Config)
+
+// WithLevelDecider задает соответствие кодов статуса уровням.
+func WithLevelDecider(fn LevelDecider) Option {
+	return func(c *Config) { c.LevelDecider = fn }
+}
+
+// WithPayloadLogging включает логирование сообщений.
+func WithPayloadLogging(v bool) Option {
+	return func(c *Config) { c.LogPayloads = v }
+}
+
+// WithPayloadRedactor задает обработку сообщений перед логированием.
+func WithPayloadRedactor(fn PayloadRedactor) Option {
+	return func(c *Config) { c.PayloadRedactor = fn }
+}
+
+// DefaultLevelDecider: ошибки клиента — Info/Warn, ошибки сервера — Error.
+func DefaultLevelDecider(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound,
+		codes.AlreadyExists, codes.Unauthenticated:
+		return zapcore.InfoLevel
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+func newConfig(opts []Option) Config {
+	cfg := Config{LevelDecider: DefaultLevelDecider}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// FromContext возвращает логгер вызова; это обертка над logger.FromContext.
+func FromContext(ctx context.Context) *logger.Logger {
+	return logger.FromContext(ctx)
+}
+
+// Направления сообщений для поля message.type.
+const (
+	messageSent     = "SENT"
+	messageReceived = "RECEIVED"
+)
+
+// UnaryServerInterceptor логирует унарные вызовы на сервере.
+func UnaryServerInterceptor(l *logger.Logger, opts ...Option) grpc.UnaryServerInterceptor {
+	cfg := newConfig(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		c := newCall(ctx, l, cfg, info.FullMethod, serverPeerFields(ctx))
+		c.payload(messageReceived, req)
+
+		resp, err := handler(c.ctx, req)
+		if err == nil {
+			c.payload(messageSent, resp)
+		}
+		c.finish("grpc server call", semconv.RPCServerDurationName, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor логирует потоковые вызовы на сервере.
+func StreamServerInterceptor(l *logger.Logger, opts ...Option) grpc.StreamServerInterceptor {
+	cfg := newConfig(opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		c := newCall(ss.Context(), l, cfg, info.FullMethod, serverPeerFields(ss.Context()))
+		err := handler(srv, &serverStream{ServerStream: ss, call: c})
+		c.finish("grpc server call", semconv.RPCServerDurationName, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor логирует унарные вызовы на клиенте.
+func UnaryClientInterceptor(l *logger.Logger, opts ...Option) grpc.UnaryClientInterceptor {
+	cfg := newConfig(opts)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		c := newCall(ctx, l, cfg, method, nil)
+		c.payload(messageSent, req)
+
+		// Адрес сервера известен только после выбора соединения балансировщиком.
+		c.peer = &peer.Peer{}
+		callOpts = append(callOpts[:len(callOpts):len(callOpts)], grpc.Peer(c.peer))
+		err := invoker(c.ctx, method, req, reply, cc, callOpts...)
+		if err == nil {
+			c.payload(messageReceived, reply)
+		}
+		c.finish("grpc client call", semconv.RPCClientDurationName, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor логирует потоковые вызовы на клиенте.
+// Вызов считается завершенным, когда RecvMsg возвращает ошибку (в том числе io.EOF),
+// после единственного ответа в client-streaming вызове или когда отменяется
+// контекст вызова, например если поток брошен без чтения.
+func StreamClientInterceptor(l *logger.Logger, opts ...Option) grpc.StreamClientInterceptor {
+	cfg := newConfig(opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		c := newCall(ctx, l, cfg, method, nil)
+		cs, err := streamer(c.ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			c.finish("grpc client call", semconv.RPCClientDurationName, err)
+			return nil, err
+		}
+		// grpc.Peer заполняется только по завершении потока и конкурентно
+		// с отменой контекста, поэтому адрес берется из контекста потока.
+		if p, ok := peer.FromContext(cs.Context()); ok {
+			c.peer = p
+		}
+		s := &clientStream{ClientStream: cs, call: c, serverStreams: desc.ServerStreams}
+		s.stop = context.AfterFunc(c.ctx, func() {
+			s.finish(status.FromContextError(c.ctx.Err()).Err())
+		})
+		return s, nil
+	}
+}
+
+// call — состояние одного вызова.
+type call struct {
+	ctx        context.Context
+	log        *logger.Logger
+	cfg        Config
+	fullMethod string
+	start      time.Time
+	peer       *peer.Peer // Адрес сервера на клиенте; заполняется до finish.
+
+	mu        sync.Mutex
+	messageID int
+}
+
+func newCall(ctx context.Context, l *logger.Logger, cfg Config, fullMethod string, peerFields []zap.Field) *call {
+	service, method := splitMethod(fullMethod)
+	fields := []zap.Field{
+		zap.String(string(semconv.RPCSystemKey), semconv.RPCSystemGRPC.Value.AsString()),
+		zap.String(string(semconv.RPCServiceKey), service),
+		zap.String(string(semconv.RPCMethodKey), method),
+	}
+	fields = append(fields, peerFields...)
+	callLogger := l.BindContext(ctx).WithFields(fields...)
+	return &call{
+		ctx:        logger.ToContext(ctx, callLogger),
+		log:        callLogger,
+		cfg:        cfg,
+		fullMethod: fullMethod,
+		start:      time.Now(),
+	}
+}
+
+// payload логирует сообщение, если это включено в конфигурации.
+func (c *call) payload(direction string, msg any) {
+	if !c.cfg.LogPayloads {
+		return
+	}
+	if c.cfg.PayloadRedactor != nil {
+		msg = c.cfg.PayloadRedactor(c.fullMethod, msg)
+	}
+	if msg == nil {
+		return
+	}
+	c.mu.Lock()
+	c.messageID++
+	id := c.messageID
+	c.mu.Unlock()
+
+	c.log.Debug(c.ctx, "grpc message",
+		zap.String(string(semconv.MessageTypeKey), direction),
+		zap.Int(string(semconv.MessageIDKey), id),
+		payloadField(msg),
+	)
+}
+
+// finish пишет запись о завершении вызова.
+func (c *call) finish(msg, durationKey string, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.Int(string(semconv.RPCGRPCStatusCodeKey), int(code)),
+		zap.String("rpc.grpc.status", code.String()),
+		zap.Float64(durationKey, float64(time.Since(c.start))/float64(time.Millisecond)),
+	}
+	if c.peer != nil {
+		fields = append(fields, addrFields(c.peer.Addr, semconv.ServerAddressKey, semconv.ServerPortKey)...)
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	logAt(c.log, c.ctx, c.cfg.LevelDecider(code), msg, fields...)
+}
+
+// logAt пишет запись на заданном уровне.
+func logAt(l *logger.Logger, ctx context.Context, level zapcore.Level, msg string, fields ...zap.Field) {
+	switch level {
+	case zapcore.DebugLevel:
+		l.Debug(ctx, msg, fields...)
+	case zapcore.InfoLevel:
+		l.Info(ctx, msg, fields...)
+	case zapcore.WarnLevel:
+		l.Warn(ctx, msg, fields...)
+	default:
+		l.Error(ctx, msg, fields...)
+	}
+}
+
+// payloadField кодирует сообщение; protobuf сообщения кодируются в JSON.
+func payloadField(msg any) zap.Field {
+	if m, ok := msg.(proto.Message); ok {
+		if b, err := protojson.Marshal(m); err == nil {
+			return zap.String(payloadKey, string(b))
+		}
+	}
+	return zap.Any(payloadKey, msg)
+}
+
+// splitMethod разбирает полное имя метода вида /package.Service/Method.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	service, method := path.Split(fullMethod)
+	return strings.TrimSuffix(service, "/"), method
+}
+
+// serverPeerFields возвращает адрес клиента из контекста сервера.
+func serverPeerFields(ctx context.Context) []zap.Field {
+	if p, ok := peer.FromContext(ctx); ok {
+		return addrFields(p.Addr, semconv.NetworkPeerAddressKey, semconv.NetworkPeerPortKey)
+	}
+	return nil
+}
+
+// addrFields разделяет адрес на хост и порт. Адреса без порта
+// (bufconn, unix сокеты) пишутся целиком без поля порта.
+func addrFields(addr net.Addr, hostKey, portKey attribute.Key) []zap.Field {
+	if addr == nil {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return []zap.Field{zap.String(string(hostKey), addr.String())}
+	}
+	fields := []zap.Field{zap.String(string(hostKey), host)}
+	if n, err := strconv.Atoi(port); err == nil {
+		fields = append(fields, zap.Int(string(portKey), n))
+	}
+	return fields
+}
+
+// serverStream подменяет контекст потока и логирует сообщения.
+type serverStream struct {
+	grpc.ServerStream
+	call *call
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.call.ctx
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.call.payload(messageReceived, m)
+	}
+	return err
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.call.payload(messageSent, m)
+	}
+	return err
+}
+
+// clientStream логирует сообщения и завершение потока на клиенте.
+type clientStream struct {
+	grpc.ClientStream
+	call *call
+	once sync.Once
+	stop func() bool // Отменяет запись о завершении по отмене контекста.
+	// serverStreams — сервер отвечает потоком; иначе вызов завершается
+	// первым успешным RecvMsg (CloseAndRecv).
+	serverStreams bool
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.call.payload(messageSent, m)
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.call.payload(messageReceived, m)
+		if !s.serverStreams {
+			s.stop()
+			s.finish(nil)
+		}
+		return nil
+	}
+	s.stop()
+	if errors.Is(err, io.EOF) {
+		s.finish(nil)
+	} else {
+		s.finish(err)
+	}
+	return err
+}
+
+// finish пишет запись о завершении потока один раз.
+func (s *clientStream) finish(err error) {
+	s.once.Do(func() {
+		s.call.finish("grpc client call", semconv.RPCClientDurationName, err)
+	})
+}
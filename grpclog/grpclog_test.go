@@ -0,0 +1,303 @@
+package grpclog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	logger "github.com/major1ink/otelzap"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testServer — in-process gRPC сервер с health сервисом.
+type testServer struct {
+	server *grpc.Server
+	client healthpb.HealthClient
+	test   testpb.TestServiceClient
+}
+
+// testService реализует client-streaming метод StreamingInputCall.
+type testService struct {
+	testpb.UnimplementedTestServiceServer
+}
+
+func (testService) StreamingInputCall(stream testpb.TestService_StreamingInputCallServer) error {
+	var size int32
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&testpb.StreamingInputCallResponse{AggregatedPayloadSize: size})
+		}
+		if err != nil {
+			return err
+		}
+		size += int32(len(req.GetPayload().GetBody()))
+	}
+}
+
+func newTestServer(t *testing.T, serverLog, clientLog *logger.Logger, opts ...Option) *testServer {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+
+	// Проверяет, что логгер вызова доступен обработчику.
+	handlerLog := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		FromContext(ctx).Info(ctx, "handler")
+		return handler(ctx, req)
+	}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(serverLog, opts...), handlerLog),
+		grpc.StreamInterceptor(StreamServerInterceptor(serverLog, opts...)),
+	)
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthSrv)
+	testpb.RegisterTestServiceServer(server, testService{})
+	go func() { _ = server.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(clientLog, opts...)),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(clientLog, opts...)),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return &testServer{server: server, client: healthpb.NewHealthClient(conn), test: testpb.NewTestServiceClient(conn)}
+}
+
+// finished возвращает записи о завершении вызовов.
+func finished(rec *logger.RecordingLogger, msg string) []observer.LoggedEntry {
+	return rec.Logs().FilterMessage(msg).All()
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	serverRec, clientRec := logger.NewRecordingLogger(), logger.NewRecordingLogger()
+	ts := newTestServer(t, serverRec.Logger, clientRec.Logger)
+
+	ctx := logger.ContextWithTraceID(context.Background(), "12345")
+	if _, err := ts.client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	_, err := ts.client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound, got %v", err)
+	}
+	ts.server.GracefulStop()
+
+	server := finished(serverRec, "grpc server call")
+	if len(server) != 2 {
+		t.Fatalf("Expected 2 server entries, got %d", len(server))
+	}
+	fields := server[0].ContextMap()
+	want := map[string]interface{}{
+		"rpc.system":           "grpc",
+		"rpc.service":          "grpc.health.v1.Health",
+		"rpc.method":           "Check",
+		"rpc.grpc.status_code": int64(codes.OK),
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("Expected %s=%v, got %v", k, v, fields[k])
+		}
+	}
+	if _, ok := fields["network.peer.address"]; !ok {
+		t.Error("Expected peer address field")
+	}
+	if _, ok := fields["rpc.server.duration"]; !ok {
+		t.Error("Expected duration field")
+	}
+	if got := server[1].ContextMap()["rpc.grpc.status_code"]; got != int64(codes.NotFound) {
+		t.Errorf("Expected NotFound status code, got %v", got)
+	}
+
+	handler := finished(serverRec, "handler")
+	if len(handler) != 2 || handler[0].ContextMap()["rpc.method"] != "Check" {
+		t.Errorf("Expected handler entries with call fields, got %v", handler)
+	}
+
+	client := finished(clientRec, "grpc client call")
+	if len(client) != 2 {
+		t.Fatalf("Expected 2 client entries, got %d", len(client))
+	}
+	fields = client[0].ContextMap()
+	if fields["trace_id"] != "12345" || fields["server.address"] != "bufconn" {
+		t.Errorf("Expected trace_id and server address, got %v", fields)
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	serverRec, clientRec := logger.NewRecordingLogger(), logger.NewRecordingLogger()
+	ts := newTestServer(t, serverRec.Logger, clientRec.Logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := ts.client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Fatalf("Expected Canceled, got %v", err)
+	}
+	ts.server.GracefulStop()
+
+	for _, rec := range []struct {
+		name string
+		log  *logger.RecordingLogger
+		msg  string
+	}{
+		{"server", serverRec, "grpc server call"},
+		{"client", clientRec, "grpc client call"},
+	} {
+		entries := finished(rec.log, rec.msg)
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 %s entry, got %d", rec.name, len(entries))
+		}
+		fields := entries[0].ContextMap()
+		if fields["rpc.method"] != "Watch" || fields["rpc.grpc.status_code"] != int64(codes.Canceled) {
+			t.Errorf("Unexpected %s fields: %v", rec.name, fields)
+		}
+	}
+}
+
+func TestClientStreamingCall(t *testing.T) {
+	serverRec, clientRec := logger.NewRecordingLogger(), logger.NewRecordingLogger()
+	ts := newTestServer(t, serverRec.Logger, clientRec.Logger)
+
+	// Долгоживущий контекст не отменяется: вызов завершает CloseAndRecv.
+	stream, err := ts.test.StreamingInputCall(context.Background())
+	if err != nil {
+		t.Fatalf("StreamingInputCall failed: %v", err)
+	}
+	for _, body := range []string{"ab", "cde"} {
+		if err := stream.Send(&testpb.StreamingInputCallRequest{Payload: &testpb.Payload{Body: []byte(body)}}); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("CloseAndRecv failed: %v", err)
+	}
+	if resp.GetAggregatedPayloadSize() != 5 {
+		t.Errorf("Expected aggregated size 5, got %d", resp.GetAggregatedPayloadSize())
+	}
+
+	entries := finished(clientRec, "grpc client call")
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 client entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["rpc.method"] != "StreamingInputCall" || fields["rpc.grpc.status_code"] != int64(codes.OK) {
+		t.Errorf("Unexpected client fields: %v", fields)
+	}
+}
+
+func TestAbandonedClientStream(t *testing.T) {
+	serverRec, clientRec := logger.NewRecordingLogger(), logger.NewRecordingLogger()
+	ts := newTestServer(t, serverRec.Logger, clientRec.Logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := ts.client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv failed: %v", err)
+	}
+	cancel() // Поток брошен без чтения ошибки из Recv.
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(finished(clientRec, "grpc client call")) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	entries := finished(clientRec, "grpc client call")
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 client entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["rpc.grpc.status_code"] != int64(codes.Canceled) || fields["server.address"] != "bufconn" {
+		t.Errorf("Unexpected client fields: %v", fields)
+	}
+}
+
+func TestPayloadLoggingAndLevels(t *testing.T) {
+	serverRec, clientRec := logger.NewRecordingLogger(), logger.NewRecordingLogger()
+	redact := func(fullMethod string, msg any) any {
+		if _, ok := msg.(*healthpb.HealthCheckRequest); ok {
+			return "[redacted]"
+		}
+		return msg
+	}
+	decider := func(code codes.Code) zapcore.Level {
+		if code == codes.NotFound {
+			return zapcore.ErrorLevel
+		}
+		return zapcore.DebugLevel
+	}
+	ts := newTestServer(t, serverRec.Logger, clientRec.Logger,
+		WithPayloadLogging(true), WithPayloadRedactor(redact), WithLevelDecider(decider))
+
+	_, _ = ts.client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "secret"})
+	ts.server.GracefulStop()
+
+	payloads := finished(serverRec, "grpc message")
+	if len(payloads) != 1 {
+		t.Fatalf("Expected 1 payload entry, got %d", len(payloads))
+	}
+	fields := payloads[0].ContextMap()
+	if fields["rpc.message.payload"] != "[redacted]" || fields["message.type"] != "RECEIVED" {
+		t.Errorf("Expected redacted received payload, got %v", fields)
+	}
+	if e := finished(serverRec, "grpc server call"); len(e) != 1 || e[0].Level != zapcore.ErrorLevel {
+		t.Errorf("Expected error-level call entry, got %v", e)
+	}
+}
+
+func TestAddrFields(t *testing.T) {
+	tests := []struct {
+		addr net.Addr
+		want map[string]interface{}
+	}{
+		{&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50051}, map[string]interface{}{"server.address": "10.0.0.1", "server.port": int64(50051)}},
+		{&net.TCPAddr{IP: net.ParseIP("::1"), Port: 443}, map[string]interface{}{"server.address": "::1", "server.port": int64(443)}},
+		{&net.UnixAddr{Name: "/tmp/grpc.sock", Net: "unix"}, map[string]interface{}{"server.address": "/tmp/grpc.sock"}},
+		{nil, map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		enc := zapcore.NewMapObjectEncoder()
+		for _, f := range addrFields(tt.addr, semconv.ServerAddressKey, semconv.ServerPortKey) {
+			f.AddTo(enc)
+		}
+		if len(enc.Fields) != len(tt.want) {
+			t.Errorf("addrFields(%v) = %v, want %v", tt.addr, enc.Fields, tt.want)
+			continue
+		}
+		for k, v := range tt.want {
+			if got := enc.Fields[k]; got != v {
+				t.Errorf("addrFields(%v)[%s] = %v (%T), want %v", tt.addr, k, got, got, v)
+			}
+		}
+	}
+}
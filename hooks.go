@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// terminalHook доставляет OTLP записи до завершения программы.
+// На Fatal провайдер выгружается и останавливается, на Panic/DPanic — только
+// выгружается, так как паника может быть перехвачена и логгер продолжит работу.
+type terminalHook struct {
+	otlp     *otlpState
+	timeout  time.Duration
+	shutdown bool                   // Останавливать провайдер (Fatal) или только выгружать (Panic).
+	next     zapcore.CheckWriteHook // Действие после доставки: выход или паника.
+}
+
+// newFatalHook создает hook, который останавливает провайдер и завершает программу.
+func newFatalHook(otlp *otlpState, timeout time.Duration) terminalHook {
+	return terminalHook{otlp: otlp, timeout: timeout, shutdown: true, next: zapcore.WriteThenFatal}
+}
+
+// newPanicHook создает hook, который выгружает провайдер и паникует.
+func newPanicHook(otlp *otlpState, timeout time.Duration) terminalHook {
+	return terminalHook{otlp: otlp, timeout: timeout, next: zapcore.WriteThenPanic}
+}
+
+// OnWrite реализует zapcore.CheckWriteHook.
+func (h terminalHook) OnWrite(ce *zapcore.CheckedEntry, fields []zapcore.Field) {
+	if provider := h.otlp.currentProvider(); provider != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+		if err := h.deliver(ctx, provider); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to deliver OTLP logs: %v\n", err)
+		}
+		cancel()
+	}
+	h.next.OnWrite(ce, fields)
+}
+
+func (h terminalHook) deliver(ctx context.Context, provider *otelLogSdk.LoggerProvider) error {
+	if h.shutdown {
+		return provider.Shutdown(ctx)
+	}
+	return provider.ForceFlush(ctx)
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// recordingHook фиксирует вызов вместо выхода из программы.
+type recordingHook struct {
+	called *bool
+}
+
+func (h recordingHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) { *h.called = true }
+
+// newBatchLogger создает логгер с batch процессором поверх memoryExporter.
+func newBatchLogger(exporter *memoryExporter) (*Logger, *otelLogSdk.LoggerProvider) {
+	provider := otelLogSdk.NewLoggerProvider(otelLogSdk.WithProcessor(
+		otelLogSdk.NewBatchProcessor(exporter, otelLogSdk.WithExportInterval(time.Hour)),
+	))
+	core := NewSimpleOTLPCore(provider.Logger("test"), nil, zapcore.DebugLevel, 0)
+	return &Logger{
+		zapLogger: zap.New(core),
+		levels:    newLevels(zapcore.DebugLevel),
+	}, provider
+}
+
+func TestFatalHookDeliversRecords(t *testing.T) {
+	exporter := &memoryExporter{}
+	var exited bool
+	l, provider := newBatchLogger(exporter)
+	hook := newFatalHook(&otlpState{provider: provider}, time.Second)
+	hook.next = recordingHook{called: &exited}
+	l.zapLogger = l.zapLogger.WithOptions(zap.WithFatalHook(hook))
+
+	l.Info(context.Background(), "before")
+	l.Fatal(context.Background(), "fatal")
+
+	if !exited {
+		t.Fatalf("Expected exit hook to be called")
+	}
+	if len(exporter.records) != 2 {
+		t.Fatalf("Expected 2 exported records before exit, got %d", len(exporter.records))
+	}
+	if got := exporter.records[1].Body().AsString(); got != "fatal" {
+		t.Errorf("Expected fatal record to be exported, got %q", got)
+	}
+}
+
+func TestPanicHookFlushesRecords(t *testing.T) {
+	exporter := &memoryExporter{}
+	l, provider := newBatchLogger(exporter)
+	l.zapLogger = l.zapLogger.WithOptions(zap.WithPanicHook(newPanicHook(&otlpState{provider: provider}, time.Second)))
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("Expected panic with message boom, got %v", r)
+			}
+		}()
+		l.Panic(context.Background(), "boom")
+	}()
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("Expected panic record to be flushed, got %d records", len(exporter.records))
+	}
+	// Провайдер не остановлен: после перехвата паники логирование продолжается.
+	l.Info(context.Background(), "after")
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("Failed to flush: %v", err)
+	}
+	if len(exporter.records) != 2 {
+		t.Errorf("Expected logging to continue after panic, got %d records", len(exporter.records))
+	}
+}
+
+func TestDPanicDevelopment(t *testing.T) {
+	l, err := NewLogger(context.Background(), WithEnableStdout(true), WithDevelopment(true))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected DPanic to panic in development mode")
+		}
+	}()
+	l.DPanic(context.Background(), "development panic")
+}
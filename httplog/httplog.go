@@ -0,0 +1,290 @@
+// Package httplog содержит net/http middleware, создающее логгер запроса
+// и записывающее строку access-лога.
+package httplog
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	logger "github.com/major1ink/otelzap"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RequestIDHeader — заголовок с идентификатором запроса.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDField — поле с идентификатором запроса.
+const requestIDField = "request_id"
+
+// Config — конфигурация middleware.
+type Config struct {
+	StatusLevels map[int]zapcore.Level         // Уровень access-лога по классу статуса (2 для 2xx и т.д.).
+	SkipPaths    map[string]struct{}           // Пути без access-лога, например /healthz.
+	Propagator   propagation.TextMapPropagator // Извлекает контекст трассировки из заголовков.
+}
+
+// Option — функция для настройки Config.
+type Option func(c *Config)
+
+// WithStatusLevel задает уровень access-лога для класса статуса (1–5).
+func WithStatusLevel(class int, level zapcore.Level) Option {
+	return func(c *Config) { c.StatusLevels[class] = level }
+}
+
+// WithSkipPaths отключает access-лог для указанных путей.
+// Логгер запроса для них все равно создается.
+func WithSkipPaths(paths ...string) Option {
+	return func(c *Config) {
+		for _, p := range paths {
+			c.SkipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithPropagator задает propagator вместо W3C Trace Context.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *Config) { c.Propagator = p }
+}
+
+// requestIDKey — ключ контекста для идентификатора запроса.
+type requestIDKey struct{}
+
+// FromContext возвращает логгер запроса; это обертка над logger.FromContext.
+func FromContext(ctx context.Context) *logger.Logger {
+	return logger.FromContext(ctx)
+}
+
+// RequestID возвращает идентификатор запроса из контекста.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Middleware возвращает middleware, которое:
+//   - извлекает контекст трассировки (traceparent) из заголовков;
+//   - берет X-Request-ID из запроса (до 128 символов [A-Za-z0-9._-]) или
+//     генерирует новый и возвращает его в ответе;
+//   - кладет в контекст логгер запроса, привязанный к контексту трассировки
+//     (см. FromContext);
+//   - пишет строку access-лога по завершении запроса.
+func Middleware(l *logger.Logger, opts ...Option) func(http.Handler) http.Handler {
+	cfg := Config{
+		StatusLevels: map[int]zapcore.Level{
+			1: zapcore.InfoLevel,
+			2: zapcore.InfoLevel,
+			3: zapcore.InfoLevel,
+			4: zapcore.WarnLevel,
+			5: zapcore.ErrorLevel,
+		},
+		SkipPaths:  map[string]struct{}{},
+		Propagator: propagation.TraceContext{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := r.Context()
+			// Спан, созданный внешним middleware (например, otelhttp), имеет приоритет.
+			if !trace.SpanContextFromContext(ctx).IsValid() {
+				ctx = cfg.Propagator.Extract(ctx, propagation.HeaderCarrier(r.Header))
+			}
+
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			ctx = context.WithValue(ctx, requestIDKey{}, id)
+
+			reqLogger := l.BindContext(ctx).WithFields(zap.String(requestIDField, id))
+			ctx = logger.ToContext(ctx, reqLogger)
+
+			rw := &responseWriter{ResponseWriter: w}
+			r = r.WithContext(ctx)
+			next.ServeHTTP(rw, r)
+
+			if _, skip := cfg.SkipPaths[r.URL.Path]; skip {
+				return
+			}
+			fields := []zap.Field{
+				zap.String(string(semconv.HTTPRequestMethodKey), r.Method),
+				zap.String(string(semconv.URLPathKey), r.URL.Path),
+				zap.Int(string(semconv.HTTPResponseStatusCodeKey), rw.statusCode()),
+				zap.Int64(string(semconv.HTTPResponseBodySizeKey), rw.bytes),
+				zap.Float64(semconv.HTTPServerRequestDurationName, time.Since(start).Seconds()),
+				zap.String(string(semconv.ClientAddressKey), clientAddress(r)),
+			}
+			if route := route(r); route != "" {
+				fields = append(fields, zap.String(string(semconv.HTTPRouteKey), route))
+			}
+			if ua := r.UserAgent(); ua != "" {
+				fields = append(fields, zap.String(string(semconv.UserAgentOriginalKey), ua))
+			}
+
+			level, ok := cfg.StatusLevels[rw.statusCode()/100]
+			if !ok {
+				level = zapcore.ErrorLevel
+			}
+			logAt(reqLogger, ctx, level, "http request", fields...)
+		})
+	}
+}
+
+// logAt пишет запись на заданном уровне.
+func logAt(l *logger.Logger, ctx context.Context, level zapcore.Level, msg string, fields ...zap.Field) {
+	switch level {
+	case zapcore.DebugLevel:
+		l.Debug(ctx, msg, fields...)
+	case zapcore.InfoLevel:
+		l.Info(ctx, msg, fields...)
+	case zapcore.WarnLevel:
+		l.Warn(ctx, msg, fields...)
+	default:
+		l.Error(ctx, msg, fields...)
+	}
+}
+
+// route возвращает шаблон пути из http.ServeMux без метода и хоста.
+func route(r *http.Request) string {
+	pattern := r.Pattern
+	if _, rest, ok := strings.Cut(pattern, " "); ok {
+		pattern = rest
+	}
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+	return pattern
+}
+
+// clientAddress возвращает IP клиента из RemoteAddr.
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// maxRequestIDLen — максимальная длина X-Request-ID из запроса.
+const maxRequestIDLen = 128
+
+// validRequestID проверяет длину и символы идентификатора из запроса,
+// чтобы в лог и ответ не попадали произвольные данные клиента.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID генерирует случайный идентификатор запроса.
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// responseWriter запоминает статус и размер ответа.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Flush реализует http.Flusher, если его поддерживает исходный writer.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack реализует http.Hijacker для websocket и других upgrade-соединений.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("httplog: %T does not implement http.Hijacker", w.ResponseWriter)
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// ReadFrom реализует io.ReaderFrom, сохраняя sendfile у исходного writer.
+func (w *responseWriter) ReadFrom(src io.Reader) (int64, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	var n int64
+	var err error
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(src)
+	} else {
+		n, err = io.Copy(w.ResponseWriter, src)
+	}
+	w.bytes += n
+	return n, err
+}
+
+// Push реализует http.Pusher, если его поддерживает исходный writer.
+func (w *responseWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Unwrap возвращает исходный writer для http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *responseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
@@ -0,0 +1,195 @@
+package httplog
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logger "github.com/major1ink/otelzap"
+	"go.uber.org/zap/zapcore"
+)
+
+const traceparent = "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"
+
+func newServer(rec *logger.RecordingLogger, opts ...Option) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info(r.Context(), "loading user")
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+	return Middleware(rec.Logger, opts...)(mux)
+}
+
+func TestMiddleware(t *testing.T) {
+	rec := logger.NewRecordingLogger()
+	h := newServer(rec)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("traceparent", traceparent)
+	req.Header.Set(RequestIDHeader, "req-1")
+	req.RemoteAddr = "10.0.0.1:1234"
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+
+	if got := resp.Header().Get(RequestIDHeader); got != "req-1" {
+		t.Errorf("Expected request id req-1 in response, got %q", got)
+	}
+	entries := rec.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	for _, e := range entries {
+		fields := e.ContextMap()
+		if fields["trace_id"] != "0102030405060708090a0b0c0d0e0f10" || fields["request_id"] != "req-1" {
+			t.Errorf("Expected trace_id and request_id in %q, got %v", e.Message, fields)
+		}
+	}
+
+	access := entries[1]
+	if access.Level != zapcore.InfoLevel {
+		t.Errorf("Expected info level, got %v", access.Level)
+	}
+	fields := access.ContextMap()
+	want := map[string]interface{}{
+		"http.request.method":       "GET",
+		"http.route":                "/users/{id}",
+		"url.path":                  "/users/42",
+		"http.response.status_code": int64(200),
+		"http.response.body.size":   int64(2),
+		"client.address":            "10.0.0.1",
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("Expected %s=%v, got %v", k, v, fields[k])
+		}
+	}
+	if _, ok := fields["http.server.request.duration"]; !ok {
+		t.Error("Expected request duration field")
+	}
+}
+
+func TestMiddlewareGeneratesRequestID(t *testing.T) {
+	rec := logger.NewRecordingLogger()
+	resp := httptest.NewRecorder()
+	newServer(rec).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	id := resp.Header().Get(RequestIDHeader)
+	if len(id) != 32 {
+		t.Fatalf("Expected generated request id, got %q", id)
+	}
+	if got := rec.Entries()[1].ContextMap()["request_id"]; got != id {
+		t.Errorf("Expected request_id=%s, got %v", id, got)
+	}
+}
+
+func TestMiddlewareRejectsInvalidRequestID(t *testing.T) {
+	for name, id := range map[string]string{
+		"too long":      strings.Repeat("a", 129),
+		"bad character": "req 1\r\nX-Injected: 1",
+		"non-ascii":     "запрос",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := logger.NewRecordingLogger()
+			req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			req.Header.Set(RequestIDHeader, id)
+			resp := httptest.NewRecorder()
+			newServer(rec).ServeHTTP(resp, req)
+
+			if got := resp.Header().Get(RequestIDHeader); got == id || len(got) != 32 {
+				t.Errorf("Expected generated request id, got %q", got)
+			}
+		})
+	}
+}
+
+func TestMiddlewareHijack(t *testing.T) {
+	rec := logger.NewRecordingLogger()
+	h := Middleware(rec.Logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 2\r\nConnection: close\r\n\r\nhi")
+		_ = buf.Flush()
+	}))
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		h.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "hi" {
+		t.Errorf("Expected hijacked response, got %q", body)
+	}
+	<-done
+	entries := rec.Entries()
+	if len(entries) != 1 || entries[0].ContextMap()["http.response.status_code"] != int64(http.StatusSwitchingProtocols) {
+		t.Errorf("Expected access log with status 101, got %v", entries)
+	}
+}
+
+func TestMiddlewareLevelsAndSkip(t *testing.T) {
+	rec := logger.NewRecordingLogger()
+	h := newServer(rec, WithSkipPaths("/healthz"), WithStatusLevel(4, zapcore.DebugLevel))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if e := entries[0]; e.Level != zapcore.DebugLevel || e.ContextMap()["url.path"] != "/missing" {
+		t.Errorf("Expected debug entry for /missing, got %v %v", e.Level, e.ContextMap())
+	}
+}
+
+func TestRequestLoggerBoundToContext(t *testing.T) {
+	rec := logger.NewRecordingLogger()
+	h := Middleware(rec.Logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info(context.Background(), "detached")
+		FromContext(r.Context()).Info(r.Context(), "attached")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", traceparent)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	for _, msg := range []string{"detached", "attached"} {
+		entries := rec.Logs().FilterMessage(msg).All()
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 %q entry, got %d", msg, len(entries))
+		}
+		var traceIDs int
+		for _, f := range entries[0].Context {
+			if f.Key == "trace_id" {
+				traceIDs++
+			}
+		}
+		if traceIDs != 1 {
+			t.Errorf("Expected exactly one trace_id in %q, got %d: %v", msg, traceIDs, entries[0].Context)
+		}
+	}
+}
+
+func TestRequestIDWithoutMiddleware(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if RequestID(req.Context()) != "" {
+		t.Error("Expected empty request id")
+	}
+}
@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Interface — общий интерфейс логгеров пакета. Его реализуют *Logger,
+// *NoopLogger и *RecordingLogger, поэтому сервисы могут зависеть от
+// интерфейса и подменять реализацию в тестах. With и WithContext возвращают
+// Interface, чтобы реализация сохранялась в производных логгерах.
+type Interface interface {
+	Debug(ctx context.Context, msg string, fields ...zap.Field)
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Warn(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+	Fatal(ctx context.Context, msg string, fields ...zap.Field)
+	With(fields ...zap.Field) Interface
+	WithContext(ctx context.Context) Interface
+	SetLevel(levelStr string) error
+	Sync() error
+	Close() error
+}
+
+var (
+	_ Interface = (*Logger)(nil)
+	_ Interface = (*NoopLogger)(nil)
+	_ Interface = (*RecordingLogger)(nil)
+)
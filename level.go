@@ -3,10 +3,21 @@ package logger
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// Sink идентифицирует приёмник логов.
+type Sink string
+
+const (
+	SinkStdout Sink = "stdout" // Вывод в stdout.
+	SinkOTLP   Sink = "otlp"   // Экспорт в OTLP.
+	SinkFile   Sink = "file"   // Запись в файл (см. WithFileOutput).
+)
+
 // parseLevel конвертирует строку в zapcore.Level.
 func parseLevel(levelStr string) (zapcore.Level, error) {
 	switch strings.ToLower(levelStr) {
@@ -30,3 +41,112 @@ func parseLevelDefault(levelStr string) zapcore.Level {
 	level, _ := parseLevel(levelStr)
 	return level
 }
+
+// sinkLevel — уровень приёмника: собственный, если задан, иначе общий.
+type sinkLevel struct {
+	global zap.AtomicLevel
+	own    zap.AtomicLevel
+	hasOwn atomic.Bool
+}
+
+func newSinkLevel(global zap.AtomicLevel) *sinkLevel {
+	return &sinkLevel{global: global, own: zap.NewAtomicLevel()}
+}
+
+// Enabled реализует zapcore.LevelEnabler.
+func (s *sinkLevel) Enabled(level zapcore.Level) bool {
+	return s.Level().Enabled(level)
+}
+
+// Level возвращает действующий уровень приёмника.
+func (s *sinkLevel) Level() zapcore.Level {
+	if s.hasOwn.Load() {
+		return s.own.Level()
+	}
+	return s.global.Level()
+}
+
+// set задаёт собственный уровень приёмника.
+func (s *sinkLevel) set(level zapcore.Level) {
+	s.own.SetLevel(level)
+	s.hasOwn.Store(true)
+}
+
+// reset возвращает приёмник к общему уровню.
+func (s *sinkLevel) reset() {
+	s.hasOwn.Store(false)
+}
+
+// levels хранит общий уровень и уровни приёмников.
+// Разделяется между всеми логгерами, полученными через With/WithContext.
+type levels struct {
+	global zap.AtomicLevel
+	sinks  map[Sink]*sinkLevel
+}
+
+func newLevels(level zapcore.Level) *levels {
+	global := zap.NewAtomicLevelAt(level)
+	return &levels{
+		global: global,
+		sinks: map[Sink]*sinkLevel{
+			SinkStdout: newSinkLevel(global),
+			SinkOTLP:   newSinkLevel(global),
+			SinkFile:   newSinkLevel(global),
+		},
+	}
+}
+
+// sink возвращает уровень приёмника.
+func (lv *levels) sink(s Sink) (*sinkLevel, error) {
+	sl, ok := lv.sinks[s]
+	if !ok {
+		return nil, fmt.Errorf("unknown sink: %s", s)
+	}
+	return sl, nil
+}
+
+// setSink задаёт уровень приёмника из строки; пустая строка сбрасывает к общему уровню.
+func (lv *levels) setSink(s Sink, levelStr string) error {
+	sl, err := lv.sink(s)
+	if err != nil {
+		return err
+	}
+	if levelStr == "" {
+		sl.reset()
+		return nil
+	}
+	level, err := parseLevel(levelStr)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+	sl.set(level)
+	return nil
+}
+
+// levelCore ограничивает core дополнительным уровнем.
+type levelCore struct {
+	zapcore.Core
+	level zapcore.LevelEnabler
+}
+
+func newLevelCore(core zapcore.Core, level zapcore.LevelEnabler) zapcore.Core {
+	return &levelCore{Core: core, level: level}
+}
+
+// Enabled реализует zapcore.Core.
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level) && c.Core.Enabled(level)
+}
+
+// With реализует zapcore.Core.
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+// Check реализует zapcore.Core.
+func (c *levelCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(entry.Level) {
+		return ce
+	}
+	return c.Core.Check(entry, ce)
+}
@@ -4,38 +4,43 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"strings"
 	"time"
 
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	otelLog "go.opentelemetry.io/otel/log"
 	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
-	"go.opentelemetry.io/otel/sdk/resource"
-	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 // Logger обёртка над zap.Logger с поддержкой контекста и OTLP.
 type Logger struct {
-	zapLogger    *zap.Logger
-	otelProvider *otelLogSdk.LoggerProvider
-	config       Config
+	zapLogger *zap.Logger
+	otlp      *otlpState
+	levels    *levels
+	sampling  *samplingState
+	file      *fileOutput
+	config    Config
+	bound     []zap.Field // Поля контекста, привязанные через BindContext.
 }
 
 // NewLogger создает новый экземпляр логгера.
 func NewLogger(ctx context.Context, opts ...Option) (*Logger, error) {
 	cfg := Config{
-		AsJSON:          true,
-		EnableOTLP:      false,
-		EnableStdout:    true,
-		Level:           "info",
-		ShutdownTimeout: 2 * time.Second,
+		AsJSON:            true,
+		EnableOTLP:        false,
+		EnableStdout:      true,
+		Level:             "info",
+		ShutdownTimeout:   2 * time.Second,
+		ResourceDetectors: DefaultResourceDetectors(),
+		LogrVerbosity:     defaultLogrVerbosity,
 	}
 
-	for _, o := range opts {
-		o(&cfg)
+	cfg, err := applyOptions(cfg, opts, os.Getenv)
+	if err != nil {
+		return nil, err
 	}
 
 	level, err := parseLevel(cfg.Level)
@@ -43,85 +48,147 @@ func NewLogger(ctx context.Context, opts ...Option) (*Logger, error) {
 		return nil, fmt.Errorf("invalid log level: %w", err)
 	}
 
-	cores, otelProvider, err := buildCores(ctx, cfg, level)
+	lv := newLevels(level)
+	if err := lv.setSink(SinkStdout, cfg.StdoutLevel); err != nil {
+		return nil, fmt.Errorf("invalid stdout level: %w", err)
+	}
+	if err := lv.setSink(SinkOTLP, cfg.OtlpLevel); err != nil {
+		return nil, fmt.Errorf("invalid OTLP level: %w", err)
+	}
+	if err := lv.setSink(SinkFile, cfg.FileLevel); err != nil {
+		return nil, fmt.Errorf("invalid file level: %w", err)
+	}
+
+	sampling, err := newSamplingState(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sampling: %w", err)
+	}
+
+	redact, err := newRedactor(cfg.Redaction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction: %w", err)
+	}
+
+	cores, otlp, file, err := buildCores(ctx, cfg, lv, sampling)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build cores: %w", err)
 	}
 
-	zapLogger := zap.New(
-		zapcore.NewTee(cores...),
+	zapOpts := []zap.Option{
 		zap.AddCaller(),
 		zap.AddCallerSkip(1),
-	)
-
-	return &Logger{
-		zapLogger:    zapLogger,
-		otelProvider: otelProvider,
-		config:       cfg,
-	}, nil
+		zap.WithFatalHook(newFatalHook(otlp, cfg.ShutdownTimeout)),
+		zap.WithPanicHook(newPanicHook(otlp, cfg.ShutdownTimeout)),
+	}
+	if cfg.Development {
+		zapOpts = append(zapOpts, zap.Development())
+	}
+	zapLogger := zap.New(sampling.wrapTee(redact.wrap(zapcore.NewTee(cores...))), zapOpts...)
+	sampling.start()
+
+	l := &Logger{
+		zapLogger: zapLogger,
+		otlp:      otlp,
+		levels:    lv,
+		sampling:  sampling,
+		file:      file,
+		config:    cfg,
+	}
+	if cfg.SlogDefault {
+		slog.SetDefault(NewSlogLogger(l))
+	}
+	return l, nil
 }
 
 // buildCores создает слайс cores для zapcore.Tee.
-func buildCores(ctx context.Context, cfg Config, level zapcore.Level) ([]zapcore.Core, *otelLogSdk.LoggerProvider, error) {
+// Скрытие данных применяется к Tee целиком в NewLogger.
+func buildCores(ctx context.Context, cfg Config, lv *levels, sampling *samplingState) ([]zapcore.Core, *otlpState, *fileOutput, error) {
 	var cores []zapcore.Core
-	var otelProvider *otelLogSdk.LoggerProvider
+	otlp := &otlpState{}
 
 	if cfg.EnableStdout {
-		stdoutCore := createStdoutCore(cfg.AsJSON, level)
-		cores = append(cores, stdoutCore)
+		stdoutCore := createStdoutCore(cfg.AsJSON, lv.sinks[SinkStdout])
+		cores = append(cores, sampling.wrapSink(SinkStdout, stdoutCore))
 	}
 
 	if cfg.EnableOTLP {
-		otlpCore, provider, err := createOTLPCore(ctx, cfg, level)
+		build := func(ctx context.Context) (zapcore.Core, *otelLogSdk.LoggerProvider, error) {
+			return createOTLPCore(ctx, cfg, lv.sinks[SinkOTLP])
+		}
+		otlpCore, err := otlp.buildOTLPCore(ctx, cfg.OtlpFailurePolicy, build)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cores = append(cores, sampling.wrapSink(SinkOTLP, otlpCore))
+	}
+
+	var file *fileOutput
+	if cfg.FilePath != "" {
+		var err error
+		file, err = newFileOutput(cfg.FilePath, cfg.FileRotation)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to create OTLP core: %v\n", err)
-			cores = append(cores, zapcore.NewNopCore())
-		} else {
-			cores = append(cores, otlpCore)
-			otelProvider = provider
+			otlp.stopRetry()
+			return nil, nil, nil, err
 		}
+		fileCore := createFileCore(cfg.AsJSON, file.ws, lv.sinks[SinkFile])
+		cores = append(cores, sampling.wrapSink(SinkFile, fileCore))
+	}
+
+	// Дополнительные cores не относятся к приёмникам и подчиняются только общему сэмплеру.
+	for _, core := range cfg.Cores {
+		cores = append(cores, sampling.wrapSink("", newLevelCore(core, lv.global)))
 	}
 
 	if len(cores) == 0 {
-		return nil, nil, fmt.Errorf("no cores configured")
+		otlp.stopRetry()
+		return nil, nil, nil, fmt.Errorf("no cores configured")
 	}
-	return cores, otelProvider, nil
+	return cores, otlp, file, nil
 }
 
 // createStdoutCore создает core для вывода в stdout.
-func createStdoutCore(asJSON bool, level zapcore.Level) zapcore.Core {
+func createStdoutCore(asJSON bool, level zapcore.LevelEnabler) zapcore.Core {
+	return zapcore.NewCore(newEncoder(asJSON), &noSyncWriter{os.Stdout}, level)
+}
+
+// newEncoder создает JSON или консольный энкодер.
+func newEncoder(asJSON bool) zapcore.Encoder {
 	config := buildEncoderConfig()
-	var encoder zapcore.Encoder
 	if asJSON {
-		encoder = zapcore.NewJSONEncoder(config)
-	} else {
-		encoder = zapcore.NewConsoleEncoder(config)
+		return zapcore.NewJSONEncoder(config)
 	}
-	return zapcore.NewCore(encoder, &noSyncWriter{os.Stdout}, level)
+	return zapcore.NewConsoleEncoder(config)
 }
 
-func createOTLPCore(ctx context.Context, cfg Config, level zapcore.Level) (zapcore.Core, *otelLogSdk.LoggerProvider, error) {
-	otlpLogger, provider, processor, err := createOTLPLogger(ctx, cfg.OtlpEndpoint, cfg.ServiceName, cfg.ServiceEnvironment, cfg.OtlpUseTLS)
+func createOTLPCore(ctx context.Context, cfg Config, level zapcore.LevelEnabler) (zapcore.Core, *otelLogSdk.LoggerProvider, error) {
+	otlpLogger, provider, processor, err := createOTLPLogger(ctx, cfg)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create OTLP logger: %w", err)
 	}
 
-	otlpCore := NewSimpleOTLPCore(otlpLogger, processor, level, 500*time.Millisecond)
+	otlpCore := NewSimpleOTLPCore(otlpLogger, processor, level, cfg.OtlpEmitTimeout).
+		WithLoggerProvider(provider)
 
 	return otlpCore, provider, nil
 }
 
 // createOTLPLogger создает OTLP логгер.
-func createOTLPLogger(ctx context.Context, endpoint, serviceName, serviceEnvironment string, useTLS bool) (otelLog.Logger, *otelLogSdk.LoggerProvider, *otelLogSdk.BatchProcessor, error) {
-	exporter, err := createOTLPExporter(ctx, endpoint, useTLS)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+func createOTLPLogger(ctx context.Context, cfg Config) (otelLog.Logger, *otelLogSdk.LoggerProvider, otelLogSdk.Processor, error) {
+	if err := validateProcessorMode(cfg.OtlpProcessorMode); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", errInvalidOTLPConfig, err)
 	}
-	rs, err := createResource(ctx, serviceName, serviceEnvironment)
+	exporter := cfg.OtlpCustomExporter
+	if exporter == nil {
+		var err error
+		if exporter, err = createOTLPExporter(ctx, cfg); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+	}
+	rs, err := createResource(ctx, cfg)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create resource: %w", err)
 	}
-	processor := otelLogSdk.NewBatchProcessor(exporter)
+	processor := createProcessor(exporter, cfg)
 	provider := otelLogSdk.NewLoggerProvider(
 		otelLogSdk.WithResource(rs),
 		otelLogSdk.WithProcessor(processor),
@@ -129,23 +196,38 @@ func createOTLPLogger(ctx context.Context, endpoint, serviceName, serviceEnviron
 	return provider.Logger("app"), provider, processor, nil
 }
 
-// createOTLPExporter создает gRPC экспортер для OTLP.
-func createOTLPExporter(ctx context.Context, endpoint string, useTLS bool) (*otlploggrpc.Exporter, error) {
-	opts := []otlploggrpc.Option{otlploggrpc.WithEndpoint(endpoint)}
-	if !useTLS {
-		opts = append(opts, otlploggrpc.WithInsecure())
+// createProcessor создает процессор OTLP записей.
+// Синхронный режим экспортирует каждую запись сразу и подходит для CLI и тестов.
+func createProcessor(exporter otelLogSdk.Exporter, cfg Config) otelLogSdk.Processor {
+	if strings.EqualFold(cfg.OtlpProcessorMode, OTLPProcessorSimple) {
+		return otelLogSdk.NewSimpleProcessor(exporter)
+	}
+
+	b := cfg.OtlpBatch
+	var opts []otelLogSdk.BatchProcessorOption
+	if b.MaxQueueSize > 0 {
+		opts = append(opts, otelLogSdk.WithMaxQueueSize(b.MaxQueueSize))
+	}
+	if b.ExportInterval > 0 {
+		opts = append(opts, otelLogSdk.WithExportInterval(b.ExportInterval))
+	}
+	if b.ExportTimeout > 0 {
+		opts = append(opts, otelLogSdk.WithExportTimeout(b.ExportTimeout))
+	}
+	if b.MaxExportBatchSize > 0 {
+		opts = append(opts, otelLogSdk.WithExportMaxBatchSize(b.MaxExportBatchSize))
 	}
-	return otlploggrpc.New(ctx, opts...)
+	return otelLogSdk.NewBatchProcessor(exporter, opts...)
 }
 
-// createResource создает метаданные сервиса.
-func createResource(ctx context.Context, serviceName, serviceEnvironment string) (*resource.Resource, error) {
-	return resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(serviceName),
-			attribute.String("deployment.environment", serviceEnvironment),
-		),
-	)
+// validateProcessorMode проверяет режим процессора.
+func validateProcessorMode(mode string) error {
+	switch strings.ToLower(mode) {
+	case "", OTLPProcessorBatch, OTLPProcessorSimple:
+		return nil
+	default:
+		return fmt.Errorf("unknown OTLP processor mode: %s", mode)
+	}
 }
 
 // buildEncoderConfig настраивает Zap encoder.
@@ -166,16 +248,49 @@ func buildEncoderConfig() zapcore.EncoderConfig {
 	}
 }
 
-// SetLevel динамически меняет уровень логирования.
+// SetLevel динамически меняет общий уровень логирования.
+// Изменение применяется ко всем логгерам, полученным через With/WithContext.
+// Приёмники с собственным уровнем (см. SetSinkLevel) его сохраняют.
 func (l *Logger) SetLevel(levelStr string) error {
 	level, err := parseLevel(levelStr)
 	if err != nil {
 		return fmt.Errorf("invalid log level: %w", err)
 	}
-	l.zapLogger.Core().Enabled(level)
+	l.levels.global.SetLevel(level)
 	return nil
 }
 
+// Level возвращает текущий общий уровень логирования.
+func (l *Logger) Level() zapcore.Level {
+	return l.levels.global.Level()
+}
+
+// SetSinkLevel задаёт собственный уровень приёмника.
+// Пустая строка возвращает приёмник к общему уровню.
+func (l *Logger) SetSinkLevel(sink Sink, levelStr string) error {
+	return l.levels.setSink(sink, levelStr)
+}
+
+// SinkLevel возвращает действующий уровень приёмника.
+func (l *Logger) SinkLevel(sink Sink) (zapcore.Level, error) {
+	sl, err := l.levels.sink(sink)
+	if err != nil {
+		return zapcore.InfoLevel, err
+	}
+	return sl.Level(), nil
+}
+
+// OTLPStatus возвращает состояние экспорта в OTLP и последнюю ошибку.
+func (l *Logger) OTLPStatus() OTLPStatus {
+	return l.otlp.Status()
+}
+
+// DroppedCounts возвращает число записей, отброшенных сэмплированием
+// и ограничениями (см. WithSampling, WithSinkSampling, WithRateLimit).
+func (l *Logger) DroppedCounts() DropCounts {
+	return l.sampling.counts()
+}
+
 // Sync сбрасывает буферы логгера.
 func (l *Logger) Sync() error {
 	return l.zapLogger.Sync()
@@ -184,16 +299,21 @@ func (l *Logger) Sync() error {
 // Close завершает работу логгера и OTLP провайдера.
 func (l *Logger) Close() error {
 	var errs []error
+	l.sampling.close()
 	if err := l.zapLogger.Sync(); err != nil {
 		errs = append(errs, fmt.Errorf("failed to sync zap: %w", err))
 	}
-	if l.otelProvider != nil {
+	l.otlp.stopRetry()
+	if provider := l.otlp.currentProvider(); provider != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), l.config.ShutdownTimeout)
 		defer cancel()
-		if err := l.otelProvider.Shutdown(ctx); err != nil {
+		if err := provider.Shutdown(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("failed to shutdown OTLP: %w", err))
 		}
 	}
+	if err := l.file.close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close log file: %w", err))
+	}
 	if len(errs) > 0 {
 		return fmt.Errorf("close errors: %v", errs)
 	}
@@ -201,57 +321,91 @@ func (l *Logger) Close() error {
 }
 
 // With создает новый логгер с дополнительными полями.
-func (l *Logger) With(fields ...zap.Field) *Logger {
-	return &Logger{
-		zapLogger:    l.zapLogger.With(fields...),
-		otelProvider: l.otelProvider,
-		config:       l.config,
-	}
+// Возвращает *Logger, приведенный к Interface (см. WithFields).
+func (l *Logger) With(fields ...zap.Field) Interface {
+	return l.WithFields(fields...)
 }
 
 // WithContext создает логгер с полями из контекста.
-func (l *Logger) WithContext(ctx context.Context) *Logger {
+// Возвращает *Logger, приведенный к Interface (см. BindContext).
+func (l *Logger) WithContext(ctx context.Context) Interface {
+	return l.BindContext(ctx)
+}
+
+// WithFields — вариант With, возвращающий *Logger.
+func (l *Logger) WithFields(fields ...zap.Field) *Logger {
+	return l.withZap(l.zapLogger.With(fields...))
+}
+
+// BindContext — вариант WithContext, возвращающий *Logger.
+// Привязанные поля не повторяются, если запись делается с тем же контекстом.
+func (l *Logger) BindContext(ctx context.Context) *Logger {
+	fields := l.fieldsFromContext(ctx)
+	bound := l.withZap(l.zapLogger.With(append(fields, contextField(ctx))...))
+	bound.bound = append(l.bound[:len(l.bound):len(l.bound)], fields...)
+	return bound
+}
+
+// withZap создает логгер с тем же состоянием поверх другого zap.Logger.
+func (l *Logger) withZap(zapLogger *zap.Logger) *Logger {
 	return &Logger{
-		zapLogger:    l.zapLogger.With(l.fieldsFromContext(ctx)...),
-		otelProvider: l.otelProvider,
-		config:       l.config,
+		zapLogger: zapLogger,
+		otlp:      l.otlp,
+		levels:    l.levels,
+		sampling:  l.sampling,
+		file:      l.file,
+		config:    l.config,
+		bound:     l.bound,
 	}
 }
 
 // Debug логирует на уровне Debug.
 func (l *Logger) Debug(ctx context.Context, msg string, fields ...zap.Field) {
-	l.zapLogger.Debug(msg, append(l.fieldsFromContext(ctx), fields...)...)
+	l.zapLogger.Debug(msg, l.contextFields(ctx, fields)...)
 }
 
 // Info логирует на уровне Info.
 func (l *Logger) Info(ctx context.Context, msg string, fields ...zap.Field) {
-	l.zapLogger.Info(msg, append(l.fieldsFromContext(ctx), fields...)...)
+	l.zapLogger.Info(msg, l.contextFields(ctx, fields)...)
 }
 
 // Warn логирует на уровне Warn.
 func (l *Logger) Warn(ctx context.Context, msg string, fields ...zap.Field) {
-	l.zapLogger.Warn(msg, append(l.fieldsFromContext(ctx), fields...)...)
+	l.zapLogger.Warn(msg, l.contextFields(ctx, fields)...)
 }
 
 // Error логирует на уровне Error.
 func (l *Logger) Error(ctx context.Context, msg string, fields ...zap.Field) {
-	l.zapLogger.Error(msg, append(l.fieldsFromContext(ctx), fields...)...)
+	l.zapLogger.Error(msg, l.contextFields(ctx, fields)...)
+}
+
+// DPanic логирует на уровне DPanic; в режиме разработки паникует,
+// предварительно выгрузив OTLP записи.
+func (l *Logger) DPanic(ctx context.Context, msg string, fields ...zap.Field) {
+	l.zapLogger.DPanic(msg, l.contextFields(ctx, fields)...)
+}
+
+// Panic логирует на уровне Panic, выгружает OTLP записи и паникует.
+func (l *Logger) Panic(ctx context.Context, msg string, fields ...zap.Field) {
+	l.zapLogger.Panic(msg, l.contextFields(ctx, fields)...)
 }
 
-// Fatal логирует на уровне Fatal и завершает программу.
+// Fatal логирует на уровне Fatal, выгружает и останавливает OTLP провайдер
+// в пределах ShutdownTimeout и завершает программу.
 func (l *Logger) Fatal(ctx context.Context, msg string, fields ...zap.Field) {
-	l.zapLogger.Fatal(msg, append(l.fieldsFromContext(ctx), fields...)...)
+	l.zapLogger.Fatal(msg, l.contextFields(ctx, fields)...)
 }
 
-// Sugar возвращает sugared логгер.
-func (l *Logger) Sugar() *zap.SugaredLogger {
-	return l.zapLogger.Sugar()
+// Sugar возвращает sugared логгер с поддержкой контекста.
+func (l *Logger) Sugar() *SugaredLogger {
+	return newSugaredLogger(l)
 }
 
 // NewNopLogger создает no-op логгер для тестов.
 func NewNopLogger() *Logger {
 	return &Logger{
 		zapLogger: zap.NewNop(),
+		levels:    newLevels(zapcore.InfoLevel),
 	}
 }
 
@@ -259,10 +413,12 @@ func NewNopLogger() *Logger {
 func NewBenchmarkLogger() *Logger {
 	return &Logger{
 		zapLogger: zap.New(zapcore.NewNopCore()),
+		levels:    newLevels(zapcore.InfoLevel),
 	}
 }
 
-// noSyncWriter оборачивает io.Writer, игнорируя Sync.
+// noSyncWriter оборачивает io.Writer, игнорируя Sync: stdout не буферизуется,
+// а fsync для терминала или pipe возвращает ошибку.
 type noSyncWriter struct {
 	io.Writer
 }
@@ -39,10 +39,13 @@ func TestNoopLogger(t *testing.T) {
 	if err := l.Close(); err != nil {
 		t.Fatalf("Failed to close: %v", err)
 	}
+	if child := l.With(zap.String("key", "value")).WithContext(context.Background()); child != Interface(l) {
+		t.Errorf("Expected With to return the same NoopLogger, got %T", child)
+	}
 }
 
 func TestFieldsFromContext(t *testing.T) {
-	l := NewNoopLogger()
+	l := NewNopLogger()
 	ctx := context.WithValue(context.Background(), traceIDKey, "12345")
 	ctx = context.WithValue(ctx, userIDKey, "user1")
 	fields := l.fieldsFromContext(ctx)
@@ -57,6 +60,21 @@ func TestFieldsFromContext(t *testing.T) {
 	}
 }
 
+func TestFieldsFromSpanContext(t *testing.T) {
+	l := NewNopLogger()
+	ctx, sc := spanContext(t)
+	fields := l.fieldsFromContext(ctx)
+	if len(fields) != 2 {
+		t.Fatalf("Expected 2 fields, got %d", len(fields))
+	}
+	if fields[0].Key != string(traceIDKey) || fields[0].String != sc.TraceID().String() {
+		t.Errorf("Expected trace_id=%s, got %v", sc.TraceID(), fields[0])
+	}
+	if fields[1].Key != string(spanIDKey) || fields[1].String != sc.SpanID().String() {
+		t.Errorf("Expected span_id=%s, got %v", sc.SpanID(), fields[1])
+	}
+}
+
 func TestParseLevel(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -82,3 +100,88 @@ func TestParseLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestSetLevel(t *testing.T) {
+	l, err := NewLogger(context.Background(), WithLevel("info"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	child := l.WithFields(zap.String("key", "value")).BindContext(context.Background())
+	if child.zapLogger.Core().Enabled(zapcore.DebugLevel) {
+		t.Fatalf("Expected debug to be disabled")
+	}
+	if err := l.SetLevel("debug"); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+	if !child.zapLogger.Core().Enabled(zapcore.DebugLevel) {
+		t.Errorf("Expected debug to be enabled in derived logger")
+	}
+	if child.Level() != zapcore.DebugLevel {
+		t.Errorf("Expected level: %v, got: %v", zapcore.DebugLevel, child.Level())
+	}
+	if err := l.SetLevel("invalid"); err == nil {
+		t.Errorf("Expected error for invalid level")
+	}
+}
+
+func TestSinkLevel(t *testing.T) {
+	l, err := NewLogger(context.Background(), WithLevel("info"), WithOTLPLevel("warn"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	if level, _ := l.SinkLevel(SinkOTLP); level != zapcore.WarnLevel {
+		t.Errorf("Expected OTLP level: %v, got: %v", zapcore.WarnLevel, level)
+	}
+	if err := l.SetLevel("debug"); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+	if level, _ := l.SinkLevel(SinkStdout); level != zapcore.DebugLevel {
+		t.Errorf("Expected stdout level: %v, got: %v", zapcore.DebugLevel, level)
+	}
+	if level, _ := l.SinkLevel(SinkOTLP); level != zapcore.WarnLevel {
+		t.Errorf("Expected OTLP level to stay %v, got: %v", zapcore.WarnLevel, level)
+	}
+	if err := l.SetSinkLevel(SinkOTLP, ""); err != nil {
+		t.Fatalf("Failed to reset sink level: %v", err)
+	}
+	if level, _ := l.SinkLevel(SinkOTLP); level != zapcore.DebugLevel {
+		t.Errorf("Expected OTLP level to follow global %v, got: %v", zapcore.DebugLevel, level)
+	}
+	if _, err := l.SinkLevel(Sink("unknown")); err == nil {
+		t.Errorf("Expected error for unknown sink")
+	}
+}
+
+func TestRecordingLogger(t *testing.T) {
+	rec := NewRecordingLogger()
+	var l Interface = rec
+
+	ctx := ContextWithTraceID(context.Background(), "12345")
+	l.With(zap.String("component", "billing")).Info(ctx, "charged", zap.Int("amount", 10))
+	l.Debug(context.Background(), "debug message")
+
+	entries := rec.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["component"] != "billing" || fields["trace_id"] != "12345" || fields["amount"] != int64(10) {
+		t.Errorf("Expected component, trace_id and amount fields, got %v", fields)
+	}
+	if err := l.SetLevel("info"); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+	l.Debug(context.Background(), "filtered")
+	if got := rec.Logs().FilterMessage("filtered").Len(); got != 0 {
+		t.Errorf("Expected debug message to be filtered, got %d", got)
+	}
+
+	child, ok := l.WithContext(ctx).(*RecordingLogger)
+	if !ok {
+		t.Fatalf("Expected WithContext to return *RecordingLogger, got %T", l.WithContext(ctx))
+	}
+	child.Info(context.Background(), "child")
+	if child.Logs() != rec.Logs() || rec.Logs().FilterMessage("child").Len() != 1 {
+		t.Errorf("Expected derived logger to share the buffer")
+	}
+}
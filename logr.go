@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultLogrVerbosity — максимальный V-уровень logr по умолчанию.
+const defaultLogrVerbosity = 1
+
+// logrSink реализует logr.LogSink поверх Logger.
+// V(0) пишется на уровне Info, V(1) и выше — на уровне Debug;
+// V-уровни выше Config.LogrVerbosity отбрасываются.
+type logrSink struct {
+	l         *Logger
+	verbosity int
+}
+
+// Logr возвращает logr.Logger, пишущий через этот логгер.
+func (l *Logger) Logr() logr.Logger {
+	return logr.New(&logrSink{l: l, verbosity: l.config.LogrVerbosity})
+}
+
+// Init реализует logr.LogSink.
+func (s *logrSink) Init(info logr.RuntimeInfo) {
+	s.l = s.l.withZap(s.l.zapLogger.WithOptions(zap.AddCallerSkip(info.CallDepth)))
+}
+
+// Enabled реализует logr.LogSink.
+func (s *logrSink) Enabled(level int) bool {
+	return level <= s.verbosity && s.l.zapLogger.Core().Enabled(logrToZapLevel(level))
+}
+
+// Info реализует logr.LogSink.
+func (s *logrSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if ce := s.l.zapLogger.Check(logrToZapLevel(level), msg); ce != nil {
+		ce.Write(logrFields(keysAndValues)...)
+	}
+}
+
+// Error реализует logr.LogSink. Ошибка добавляется атрибутами
+// exception.message и exception.type.
+func (s *logrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	ce := s.l.zapLogger.Check(zapcore.ErrorLevel, msg)
+	if ce == nil {
+		return
+	}
+	fields := logrFields(keysAndValues)
+	if err != nil {
+		fields = append(fields,
+			zap.String("exception.message", err.Error()),
+			zap.String("exception.type", fmt.Sprintf("%T", err)),
+		)
+	}
+	ce.Write(fields...)
+}
+
+// WithValues реализует logr.LogSink.
+func (s *logrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &logrSink{l: s.l.WithFields(logrFields(keysAndValues)...), verbosity: s.verbosity}
+}
+
+// WithName реализует logr.LogSink. Имя становится именем zap логгера
+// и OTLP scope записей.
+func (s *logrSink) WithName(name string) logr.LogSink {
+	return &logrSink{l: s.l.withZap(s.l.zapLogger.Named(name)), verbosity: s.verbosity}
+}
+
+// WithCallDepth реализует logr.CallDepthLogSink.
+func (s *logrSink) WithCallDepth(depth int) logr.LogSink {
+	return &logrSink{l: s.l.withZap(s.l.zapLogger.WithOptions(zap.AddCallerSkip(depth))), verbosity: s.verbosity}
+}
+
+// logrToZapLevel сопоставляет V-уровень logr с уровнем zap.
+func logrToZapLevel(level int) zapcore.Level {
+	if level > 0 {
+		return zapcore.DebugLevel
+	}
+	return zapcore.InfoLevel
+}
+
+// logrFields конвертирует пары ключ-значение logr в поля zap.
+// Ключи, не являющиеся строками, приводятся к строке; значение
+// без ключа записывается под ключом EXTRA_VALUE_AT_END.
+func logrFields(keysAndValues []interface{}) []zap.Field {
+	fields := make([]zap.Field, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			fields = append(fields, zap.Any("EXTRA_VALUE_AT_END", keysAndValues[i]))
+			break
+		}
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields = append(fields, zap.Any(key, keysAndValues[i+1]))
+	}
+	return fields
+}
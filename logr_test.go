@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogr(t *testing.T) {
+	rec := NewRecordingLogger()
+	l := rec.Logr().WithName("controller").WithValues("kind", "Pod")
+
+	l.Info("reconciled", "name", "web-0")
+	l.V(1).Info("details")
+	l.V(2).Info("filtered by verbosity")
+	l.Error(errors.New("boom"), "failed", "attempt", 3)
+
+	entries := rec.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	want := []zapcore.Level{zapcore.InfoLevel, zapcore.DebugLevel, zapcore.ErrorLevel}
+	for i, e := range entries {
+		if e.Level != want[i] {
+			t.Errorf("Entry %d: expected level %v, got %v", i, want[i], e.Level)
+		}
+		if e.LoggerName != "controller" {
+			t.Errorf("Entry %d: expected logger name controller, got %q", i, e.LoggerName)
+		}
+		if e.ContextMap()["kind"] != "Pod" {
+			t.Errorf("Entry %d: expected kind=Pod, got %v", i, e.ContextMap())
+		}
+		if file := filepath.Base(e.Caller.File); file != "logr_test.go" {
+			t.Errorf("Entry %d: expected caller in logr_test.go, got %s", i, e.Caller.File)
+		}
+	}
+	if got := entries[0].ContextMap()["name"]; got != "web-0" {
+		t.Errorf("Expected name=web-0, got %v", got)
+	}
+	fields := entries[2].ContextMap()
+	if fields["exception.message"] != "boom" || fields["exception.type"] != "*errors.errorString" {
+		t.Errorf("Expected exception attributes, got %v", fields)
+	}
+
+	if err := rec.SetLevel("info"); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+	if l.V(1).Enabled() {
+		t.Error("Expected V(1) to be disabled at info level")
+	}
+}
+
+func TestLogrVerbosity(t *testing.T) {
+	l, err := NewLogger(context.Background(), WithLevel("debug"), WithLogrVerbosity(3))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer l.Close()
+
+	lr := l.Logr()
+	if !lr.V(3).Enabled() || lr.V(4).Enabled() {
+		t.Error("Expected V-levels up to 3 to be enabled")
+	}
+}
+
+func TestLogrOTLPScope(t *testing.T) {
+	exporter := &memoryExporter{}
+	provider := otelLogSdk.NewLoggerProvider(
+		otelLogSdk.WithProcessor(otelLogSdk.NewSimpleProcessor(exporter)),
+	)
+	core := NewSimpleOTLPCore(provider.Logger("app"), nil, zapcore.DebugLevel, 0).
+		WithLoggerProvider(provider)
+	l := &Logger{
+		zapLogger: zap.New(core),
+		levels:    newLevels(zapcore.DebugLevel),
+	}
+
+	l.Logr().WithName("controller").Info("named")
+	l.Logr().Info("unnamed")
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(exporter.records))
+	}
+	if got := exporter.records[0].InstrumentationScope().Name; got != "controller" {
+		t.Errorf("Expected scope controller, got %q", got)
+	}
+	if got := exporter.records[1].InstrumentationScope().Name; got != "app" {
+		t.Errorf("Expected scope app, got %q", got)
+	}
+}
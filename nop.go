@@ -2,17 +2,19 @@ package logger
 
 import (
 	"context"
+	"log/slog"
 
+	"github.com/go-logr/logr"
 	"go.uber.org/zap"
 )
 
-// NoopLogger реализует интерфейс Logger, игнорируя все операции логирования.
+// NoopLogger реализует Interface, игнорируя все операции логирования.
 // Используется для тестов или отключения логирования.
 type NoopLogger struct{}
 
 // NewNoopLogger создает новый no-op логгер.
-func NewNoopLogger() *Logger {
-	return &Logger{zapLogger: zap.NewNop()}
+func NewNoopLogger() *NoopLogger {
+	return &NoopLogger{}
 }
 
 // Debug игнорирует debug-сообщения.
@@ -27,17 +29,23 @@ func (l *NoopLogger) Warn(ctx context.Context, msg string, fields ...zap.Field)
 // Error игнорирует error-сообщения.
 func (l *NoopLogger) Error(ctx context.Context, msg string, fields ...zap.Field) {}
 
+// DPanic игнорирует dpanic-сообщения.
+func (l *NoopLogger) DPanic(ctx context.Context, msg string, fields ...zap.Field) {}
+
+// Panic игнорирует panic-сообщения (не паникует).
+func (l *NoopLogger) Panic(ctx context.Context, msg string, fields ...zap.Field) {}
+
 // Fatal игнорирует fatal-сообщения (не завершает программу).
 func (l *NoopLogger) Fatal(ctx context.Context, msg string, fields ...zap.Field) {}
 
-// With возвращает тот же NoopLogger.
-func (l *NoopLogger) With(fields ...zap.Field) *Logger {
-	return &Logger{zapLogger: zap.NewNop()}
+// With возвращает этот же no-op логгер.
+func (l *NoopLogger) With(fields ...zap.Field) Interface {
+	return l
 }
 
-// WithContext возвращает тот же NoopLogger.
-func (l *NoopLogger) WithContext(ctx context.Context) *Logger {
-	return &Logger{zapLogger: zap.NewNop()}
+// WithContext возвращает этот же no-op логгер.
+func (l *NoopLogger) WithContext(ctx context.Context) Interface {
+	return l
 }
 
 // SetLevel игнорирует изменение уровня.
@@ -56,6 +64,16 @@ func (l *NoopLogger) Close() error {
 }
 
 // Sugar возвращает sugared no-op логгер.
-func (l *NoopLogger) Sugar() *zap.SugaredLogger {
-	return zap.NewNop().Sugar()
+func (l *NoopLogger) Sugar() *SugaredLogger {
+	return NewNopLogger().Sugar()
+}
+
+// SlogHandler возвращает slog.Handler, игнорирующий все записи.
+func (l *NoopLogger) SlogHandler() slog.Handler {
+	return NewNopLogger().SlogHandler()
+}
+
+// Logr возвращает logr.Logger, игнорирующий все записи.
+func (l *NoopLogger) Logr() logr.Logger {
+	return logr.Discard()
 }
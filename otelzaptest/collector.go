@@ -0,0 +1,266 @@
+package otelzaptest
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// CollectedRecord — запись, полученная коллектором, вместе с ресурсом и scope.
+type CollectedRecord struct {
+	Resource *resourcepb.Resource
+	Scope    *commonpb.InstrumentationScope
+	Record   *logspb.LogRecord
+}
+
+// Attr возвращает атрибут записи по ключу.
+func (r CollectedRecord) Attr(key string) (*commonpb.AnyValue, bool) {
+	return findAttr(r.Record.GetAttributes(), key)
+}
+
+// ResourceAttr возвращает атрибут ресурса по ключу.
+func (r CollectedRecord) ResourceAttr(key string) (*commonpb.AnyValue, bool) {
+	return findAttr(r.Resource.GetAttributes(), key)
+}
+
+func findAttr(attrs []*commonpb.KeyValue, key string) (*commonpb.AnyValue, bool) {
+	for _, kv := range attrs {
+		if kv.GetKey() == key {
+			return kv.GetValue(), true
+		}
+	}
+	return nil, false
+}
+
+// Collector — OTLP коллектор логов в памяти процесса. Принимает
+// запросы по gRPC и HTTP/protobuf на случайных портах localhost.
+type Collector struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	grpcServer *grpc.Server
+	grpcAddr   string
+	httpServer *httptest.Server
+
+	mu       sync.Mutex
+	requests []*collogspb.ExportLogsServiceRequest
+	attempts int
+	failures []codes.Code // Коды ошибок для следующих запросов.
+	partial  *collogspb.ExportLogsPartialSuccess
+	latency  time.Duration
+	notify   chan struct{} // Закрывается и пересоздается при каждом принятом запросе.
+}
+
+// NewCollector запускает коллектор и останавливает его в t.Cleanup.
+func NewCollector(t testing.TB) *Collector {
+	t.Helper()
+	c := &Collector{notify: make(chan struct{})}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("otelzaptest: failed to listen: %v", err)
+	}
+	c.grpcAddr = lis.Addr().String()
+	c.grpcServer = grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(c.grpcServer, c)
+	go func() { _ = c.grpcServer.Serve(lis) }()
+
+	c.httpServer = httptest.NewServer(http.HandlerFunc(c.serveHTTP))
+
+	t.Cleanup(func() {
+		c.grpcServer.Stop()
+		c.httpServer.Close()
+	})
+	return c
+}
+
+// GRPCEndpoint возвращает адрес gRPC сервера (host:port).
+func (c *Collector) GRPCEndpoint() string {
+	return c.grpcAddr
+}
+
+// HTTPEndpoint возвращает адрес HTTP сервера (host:port); путь — /v1/logs.
+func (c *Collector) HTTPEndpoint() string {
+	return c.httpServer.Listener.Addr().String()
+}
+
+// FailNext отклоняет следующие n запросов с кодом code
+// (например, codes.Unavailable). Для HTTP код переводится в статус ответа.
+func (c *Collector) FailNext(n int, code codes.Code) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := 0; i < n; i++ {
+		c.failures = append(c.failures, code)
+	}
+}
+
+// SetPartialSuccess включает ответ partial success для всех следующих запросов.
+// Записи при этом сохраняются. rejected == 0 и пустое сообщение выключают его.
+func (c *Collector) SetPartialSuccess(rejected int64, msg string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rejected == 0 && msg == "" {
+		c.partial = nil
+		return
+	}
+	c.partial = &collogspb.ExportLogsPartialSuccess{RejectedLogRecords: rejected, ErrorMessage: msg}
+}
+
+// SetLatency задает задержку перед обработкой каждого запроса.
+func (c *Collector) SetLatency(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.latency = d
+}
+
+// Attempts возвращает число полученных запросов, включая отклоненные.
+func (c *Collector) Attempts() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.attempts
+}
+
+// Records возвращает все принятые записи.
+func (c *Collector) Records() []CollectedRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []CollectedRecord
+	for _, req := range c.requests {
+		for _, rl := range req.GetResourceLogs() {
+			for _, sl := range rl.GetScopeLogs() {
+				for _, lr := range sl.GetLogRecords() {
+					out = append(out, CollectedRecord{
+						Resource: rl.GetResource(),
+						Scope:    sl.GetScope(),
+						Record:   lr,
+					})
+				}
+			}
+		}
+	}
+	return out
+}
+
+// WaitForRecords ждет, пока коллектор примет не менее n записей,
+// и возвращает их. По истечении timeout возвращает то, что есть.
+func (c *Collector) WaitForRecords(n int, timeout time.Duration) []CollectedRecord {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		c.mu.Lock()
+		notify := c.notify
+		c.mu.Unlock()
+		if records := c.Records(); len(records) >= n {
+			return records
+		}
+		select {
+		case <-notify:
+		case <-deadline.C:
+			return c.Records()
+		}
+	}
+}
+
+// Export реализует collogspb.LogsServiceServer.
+func (c *Collector) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	resp, code := c.handle(ctx, req)
+	if code != codes.OK {
+		return nil, status.Error(code, "injected failure")
+	}
+	return resp, nil
+}
+
+// handle применяет задержку и ошибки, сохраняет запрос и формирует ответ.
+func (c *Collector) handle(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, codes.Code) {
+	c.mu.Lock()
+	latency := c.latency
+	c.mu.Unlock()
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-ctx.Done():
+			return nil, codes.DeadlineExceeded
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts++
+	if len(c.failures) > 0 {
+		code := c.failures[0]
+		c.failures = c.failures[1:]
+		return nil, code
+	}
+	c.requests = append(c.requests, req)
+	close(c.notify)
+	c.notify = make(chan struct{})
+
+	resp := &collogspb.ExportLogsServiceResponse{}
+	if c.partial != nil {
+		resp.PartialSuccess = proto.Clone(c.partial).(*collogspb.ExportLogsPartialSuccess)
+	}
+	return resp, codes.OK
+}
+
+// serveHTTP принимает OTLP/HTTP запросы в формате protobuf (в том числе gzip).
+func (c *Collector) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		body = gz
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &collogspb.ExportLogsServiceRequest{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, code := c.handle(r.Context(), req)
+	if code != codes.OK {
+		http.Error(w, "injected failure", httpStatus(code))
+		return
+	}
+	out, _ := proto.Marshal(resp)
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(out)
+}
+
+// httpStatus переводит код gRPC в статус HTTP по спецификации OTLP/HTTP.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
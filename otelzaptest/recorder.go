@@ -0,0 +1,225 @@
+// Package otelzaptest содержит средства для тестирования кода, который
+// пишет логи через otelzap: запись в память, проверки и golden-файлы.
+package otelzaptest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	logger "github.com/major1ink/otelzap"
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// UpdateGoldenEnv — переменная окружения, при установке которой
+// AssertGolden перезаписывает golden-файлы.
+const UpdateGoldenEnv = "OTELZAP_UPDATE_GOLDEN"
+
+// Recorder — логгер, сохраняющий записи zap и OTLP записи в памяти.
+// При падении теста записанные сообщения выводятся через t.Log.
+type Recorder struct {
+	*logger.Logger
+
+	t        testing.TB
+	logs     *observer.ObservedLogs
+	exporter *MemoryExporter
+}
+
+// NewRecorder создает Recorder с уровнем debug. Записи проходят через тот же
+// SimpleOTLPCore, что и в рабочем логгере, но экспортируются в память.
+// Дополнительные опции применяются поверх настроек по умолчанию.
+func NewRecorder(t testing.TB, opts ...logger.Option) *Recorder {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	exporter := &MemoryExporter{}
+	defaults := []logger.Option{
+		logger.WithLevel("debug"),
+		logger.WithEnableStdout(false),
+		logger.WithEnableOTLP(true),
+		logger.WithOTLPExporter(exporter),
+		logger.WithOTLPProcessorMode(logger.OTLPProcessorSimple),
+		logger.WithResourceDetectors(logger.ResourceDetectors{}),
+		logger.WithCore(core),
+	}
+	l, err := logger.NewLogger(context.Background(), append(defaults, opts...)...)
+	if err != nil {
+		t.Fatalf("otelzaptest: failed to create logger: %v", err)
+	}
+
+	r := &Recorder{Logger: l, t: t, logs: logs, exporter: exporter}
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("otelzaptest: captured log entries:\n%s", r.Dump())
+		}
+		_ = l.Close()
+	})
+	return r
+}
+
+// With возвращает Recorder с дополнительными полями и общими записями.
+func (r *Recorder) With(fields ...zap.Field) logger.Interface {
+	return &Recorder{Logger: r.Logger.WithFields(fields...), t: r.t, logs: r.logs, exporter: r.exporter}
+}
+
+// WithContext возвращает Recorder с полями из контекста и общими записями.
+func (r *Recorder) WithContext(ctx context.Context) logger.Interface {
+	return &Recorder{Logger: r.Logger.BindContext(ctx), t: r.t, logs: r.logs, exporter: r.exporter}
+}
+
+// Logs возвращает записанные сообщения для фильтрации.
+func (r *Recorder) Logs() *observer.ObservedLogs {
+	return r.logs
+}
+
+// Entries возвращает копию записанных сообщений.
+func (r *Recorder) Entries() []observer.LoggedEntry {
+	return r.logs.All()
+}
+
+// Records возвращает OTLP записи, которые отправил SimpleOTLPCore.
+func (r *Recorder) Records() []otelLogSdk.Record {
+	return r.exporter.Records()
+}
+
+// FilterKey возвращает сообщения, в которых есть поле key.
+func (r *Recorder) FilterKey(key string) *observer.ObservedLogs {
+	return r.logs.Filter(func(e observer.LoggedEntry) bool {
+		_, ok := e.ContextMap()[key]
+		return ok
+	})
+}
+
+// FilterValue возвращает сообщения, в которых поле key равно value.
+// Значение сравнивается с результатом кодирования поля (например, int64 для zap.Int).
+func (r *Recorder) FilterValue(key string, value any) *observer.ObservedLogs {
+	return r.logs.Filter(func(e observer.LoggedEntry) bool {
+		v, ok := e.ContextMap()[key]
+		return ok && reflect.DeepEqual(v, value)
+	})
+}
+
+// AssertLogged проверяет, что записано сообщение msg уровня level,
+// содержащее все поля fields.
+func (r *Recorder) AssertLogged(level zapcore.Level, msg string, fields ...zap.Field) {
+	r.t.Helper()
+	want := fieldMap(fields)
+	for _, e := range r.logs.FilterLevelExact(level).FilterMessage(msg).All() {
+		if containsFields(e.ContextMap(), want) {
+			return
+		}
+	}
+	r.t.Errorf("otelzaptest: no %s entry %q with fields %v", level, msg, want)
+}
+
+// AssertNotLogged проверяет, что сообщение msg уровня level не записано.
+func (r *Recorder) AssertNotLogged(level zapcore.Level, msg string) {
+	r.t.Helper()
+	if n := r.logs.FilterLevelExact(level).FilterMessage(msg).Len(); n > 0 {
+		r.t.Errorf("otelzaptest: unexpected %s entry %q (%d times)", level, msg, n)
+	}
+}
+
+// AssertGolden сравнивает записанные сообщения с golden-файлом.
+// Время и caller не учитываются. Если задана переменная UpdateGoldenEnv,
+// файл перезаписывается.
+func (r *Recorder) AssertGolden(path string) {
+	r.t.Helper()
+	got := r.Dump()
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			r.t.Fatalf("otelzaptest: failed to create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			r.t.Fatalf("otelzaptest: failed to write golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		r.t.Fatalf("otelzaptest: failed to read golden file (set %s=1 to create): %v", UpdateGoldenEnv, err)
+	}
+	if got != string(want) {
+		r.t.Errorf("otelzaptest: log output differs from %s\n--- want\n%s--- got\n%s", path, want, got)
+	}
+}
+
+// Dump возвращает записанные сообщения по одному JSON на строку.
+// Время и caller не включаются, чтобы вывод был стабильным.
+func (r *Recorder) Dump() string {
+	var b strings.Builder
+	for _, e := range r.logs.All() {
+		line := map[string]any{
+			"level":   e.Level.String(),
+			"message": e.Message,
+		}
+		if e.LoggerName != "" {
+			line["logger"] = e.LoggerName
+		}
+		if fields := e.ContextMap(); len(fields) > 0 {
+			line["fields"] = fields
+		}
+		data, err := json.Marshal(line)
+		if err != nil {
+			data = []byte(fmt.Sprintf("%q: %v", e.Message, err))
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// fieldMap кодирует поля так же, как observer.LoggedEntry.ContextMap.
+func fieldMap(fields []zap.Field) map[string]any {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return enc.Fields
+}
+
+func containsFields(got, want map[string]any) bool {
+	for k, v := range want {
+		if !reflect.DeepEqual(got[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// MemoryExporter сохраняет экспортированные OTLP записи в памяти.
+type MemoryExporter struct {
+	mu      sync.Mutex
+	records []otelLogSdk.Record
+}
+
+// Export реализует otelLogSdk.Exporter.
+func (e *MemoryExporter) Export(_ context.Context, records []otelLogSdk.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+// Shutdown реализует otelLogSdk.Exporter.
+func (e *MemoryExporter) Shutdown(context.Context) error { return nil }
+
+// ForceFlush реализует otelLogSdk.Exporter.
+func (e *MemoryExporter) ForceFlush(context.Context) error { return nil }
+
+// Records возвращает копию сохраненных записей.
+func (e *MemoryExporter) Records() []otelLogSdk.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]otelLogSdk.Record(nil), e.records...)
+}
@@ -0,0 +1,120 @@
+package otelzaptest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	logger "github.com/major1ink/otelzap"
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder(t)
+	ctx := logger.ContextWithTraceID(context.Background(), "12345")
+
+	rec.With(zap.String("component", "billing")).Info(ctx, "charged", zap.Int("amount", 10))
+	rec.Debug(context.Background(), "details")
+
+	rec.AssertLogged(zapcore.InfoLevel, "charged",
+		zap.String("component", "billing"),
+		zap.String("trace_id", "12345"),
+		zap.Int("amount", 10),
+	)
+	rec.AssertLogged(zapcore.DebugLevel, "details")
+	rec.AssertNotLogged(zapcore.ErrorLevel, "charged")
+
+	if got := rec.FilterKey("amount").Len(); got != 1 {
+		t.Errorf("Expected 1 entry with amount, got %d", got)
+	}
+	if got := rec.FilterValue("component", "billing").Len(); got != 1 {
+		t.Errorf("Expected 1 entry with component=billing, got %d", got)
+	}
+
+	if err := rec.SetLevel("info"); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+	rec.Debug(context.Background(), "filtered")
+	rec.AssertNotLogged(zapcore.DebugLevel, "filtered")
+}
+
+func TestRecorderOTLPRecords(t *testing.T) {
+	rec := NewRecorder(t)
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	rec.Warn(ctx, "slow query", zap.String("table", "orders"))
+
+	records := rec.Records()
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 OTLP record, got %d", len(records))
+	}
+	r := records[0]
+	if r.Body().AsString() != "slow query" || r.Severity() != otelLog.SeverityWarn {
+		t.Errorf("Unexpected record: %q %v", r.Body().AsString(), r.Severity())
+	}
+	if r.TraceID() != traceID || r.SpanID() != spanID {
+		t.Errorf("Expected trace %s/%s, got %s/%s", traceID, spanID, r.TraceID(), r.SpanID())
+	}
+	var table string
+	r.WalkAttributes(func(kv otelLog.KeyValue) bool {
+		if kv.Key == "table" {
+			table = kv.Value.AsString()
+		}
+		return true
+	})
+	if table != "orders" {
+		t.Errorf("Expected table=orders attribute, got %q", table)
+	}
+}
+
+func TestRecorderGolden(t *testing.T) {
+	rec := NewRecorder(t)
+	ctx := logger.ContextWithUserID(context.Background(), "user1")
+
+	rec.Info(ctx, "order created", zap.Int("order_id", 42))
+	rec.Logr().WithName("worker").Error(fmt.Errorf("boom"), "failed")
+
+	rec.AssertGolden("testdata/recorder.golden")
+}
+
+// fakeTB перехватывает ошибки и вывод Recorder.
+type fakeTB struct {
+	testing.TB
+	failed   bool
+	logs     []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper()                           {}
+func (f *fakeTB) Failed() bool                      { return f.failed }
+func (f *fakeTB) Cleanup(fn func())                 { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Errorf(format string, args ...any) { f.failed = true }
+func (f *fakeTB) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func TestRecorderDumpOnFailure(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	rec := NewRecorder(tb)
+	rec.Info(context.Background(), "visible in dump")
+	rec.AssertLogged(zapcore.InfoLevel, "missing")
+
+	for _, fn := range tb.cleanups {
+		fn()
+	}
+	if !tb.failed {
+		t.Fatal("Expected assertion failure")
+	}
+	if len(tb.logs) != 1 || !strings.Contains(tb.logs[0], "visible in dump") {
+		t.Errorf("Expected captured entries in dump, got %v", tb.logs)
+	}
+}
@@ -4,25 +4,34 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	otelLog "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap/zapcore"
 )
 
+// defaultEmitTimeout — таймаут отправки записи по умолчанию.
+const defaultEmitTimeout = 500 * time.Millisecond
+
 // SimpleOTLPCore реализует zapcore. Core для отправки логов в OTLP.
 type SimpleOTLPCore struct {
 	otlpLogger  otelLog.Logger
-	processor   *log.BatchProcessor // Для вызова ForceFlush в Sync.
+	scopes      *otlpScopes   // OTLP логгеры для именованных zap логгеров.
+	processor   log.Processor // Для вызова ForceFlush в Sync.
 	level       zapcore.LevelEnabler
 	emitTimeout time.Duration
+	attrs       []otelLog.KeyValue // Поля, добавленные через With.
+	namespace   []string           // Пространства имен, открытые через With.
+	ctx         context.Context    // Контекст, привязанный через Logger.WithContext.
 }
 
 // NewSimpleOTLPCore создает новый OTLP core.
-func NewSimpleOTLPCore(otlpLogger otelLog.Logger, processor *log.BatchProcessor, level zapcore.LevelEnabler, emitTimeout time.Duration) *SimpleOTLPCore {
+func NewSimpleOTLPCore(otlpLogger otelLog.Logger, processor log.Processor, level zapcore.LevelEnabler, emitTimeout time.Duration) *SimpleOTLPCore {
 	if emitTimeout == 0 {
-		emitTimeout = 500 * time.Millisecond
+		emitTimeout = defaultEmitTimeout
 	}
 	return &SimpleOTLPCore{
 		otlpLogger:  otlpLogger,
@@ -32,19 +41,39 @@ func NewSimpleOTLPCore(otlpLogger otelLog.Logger, processor *log.BatchProcessor,
 	}
 }
 
+// WithLoggerProvider включает выбор OTLP scope по имени zap логгера
+// (zap.Logger.Named, logr WithName). Записи безымянных логгеров
+// по-прежнему отправляются через otlpLogger.
+func (c *SimpleOTLPCore) WithLoggerProvider(provider otelLog.LoggerProvider) *SimpleOTLPCore {
+	clone := *c
+	clone.scopes = &otlpScopes{provider: provider}
+	return &clone
+}
+
 // Enabled проверяет, включен ли уровень логирования.
 func (c *SimpleOTLPCore) Enabled(level zapcore.Level) bool {
 	return c.level.Enabled(level)
 }
 
 // With добавляет поля в новый core.
+// Поля кодируются в атрибуты один раз; при совпадении ключей
+// поля из более позднего With перекрывают ранее добавленные.
 func (c *SimpleOTLPCore) With(fields []zapcore.Field) zapcore.Core {
-	return &SimpleOTLPCore{
+	attrs, namespace := encodeFields(fields)
+	clone := &SimpleOTLPCore{
 		otlpLogger:  c.otlpLogger,
+		scopes:      c.scopes,
 		processor:   c.processor,
 		level:       c.level,
 		emitTimeout: c.emitTimeout,
+		attrs:       mergeAttrsAt(c.attrs, c.namespace, attrs),
+		namespace:   append(c.namespace[:len(c.namespace):len(c.namespace)], namespace...),
+		ctx:         c.ctx,
 	}
+	if ctx, ok := contextFromFields(fields); ok {
+		clone.ctx = ctx
+	}
+	return clone
 }
 
 // Check добавляет core в CheckedEntry, если уровень включен.
@@ -59,11 +88,10 @@ func (c *SimpleOTLPCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *z
 func (c *SimpleOTLPCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
 	severity := mapZapToOtelSeverity(entry.Level)
 	record := makeBaseRecord(entry, severity)
-	if len(fields) > 0 {
-		attrs := encodeFieldsToAttrs(fields)
-		if len(attrs) > 0 {
-			record.AddAttributes(attrs...)
-		}
+	// Поля вызова имеют приоритет над полями из With и попадают
+	// в пространство имен, открытое через With.
+	if attrs := mergeAttrsAt(c.attrs, c.namespace, encodeFieldsToAttrs(fields)); len(attrs) > 0 {
+		record.AddAttributes(attrs...)
 	}
 	// Добавляем caller и stacktrace, если есть.
 	if entry.Caller.Defined {
@@ -73,14 +101,14 @@ func (c *SimpleOTLPCore) Write(entry zapcore.Entry, fields []zapcore.Field) erro
 		record.AddAttributes(otelLog.String("stacktrace", entry.Stack))
 	}
 
-	if err := c.emitWithTimeout(record); err != nil {
+	if err := c.emitWithTimeout(c.emitContext(fields), c.scopeLogger(entry.LoggerName), record); err != nil {
 		// Fallback на stderr при timeout.
 		fmt.Fprintf(os.Stderr, "failed to emit OTLP log: %v, message: %s\n", err, entry.Message)
 	}
 	return nil
 }
 
-// Sync вызывает flush для OTLP batch processor.
+// Sync вызывает flush для OTLP процессора.
 func (c *SimpleOTLPCore) Sync() error {
 	if c.processor == nil {
 		return nil
@@ -122,48 +150,93 @@ func makeBaseRecord(entry zapcore.Entry, sev otelLog.Severity) otelLog.Record {
 
 // encodeFieldsToAttrs конвертирует Zap поля в OTLP атрибуты.
 func encodeFieldsToAttrs(fields []zapcore.Field) []otelLog.KeyValue {
-	if len(fields) == 0 {
-		return nil
+	attrs, _ := encodeFields(fields)
+	return attrs
+}
+
+// mergeAttrs объединяет атрибуты; при совпадении ключей побеждает override.
+// Исходные слайсы не изменяются.
+func mergeAttrs(base, override []otelLog.KeyValue) []otelLog.KeyValue {
+	if len(override) == 0 {
+		return base
+	}
+	if len(base) == 0 {
+		return override
+	}
+	keys := make(map[string]struct{}, len(override))
+	for _, kv := range override {
+		keys[kv.Key] = struct{}{}
 	}
+	merged := make([]otelLog.KeyValue, 0, len(base)+len(override))
+	for _, kv := range base {
+		if _, ok := keys[kv.Key]; !ok {
+			merged = append(merged, kv)
+		}
+	}
+	return append(merged, override...)
+}
 
-	enc := zapcore.NewMapObjectEncoder()
-	for _, f := range fields {
-		f.AddTo(enc)
-	}
-
-	attrs := make([]otelLog.KeyValue, 0, len(enc.Fields))
-	for k, v := range enc.Fields {
-		switch val := v.(type) {
-		case string:
-			attrs = append(attrs, otelLog.String(k, val))
-		case bool:
-			attrs = append(attrs, otelLog.Bool(k, val))
-		case int64:
-			attrs = append(attrs, otelLog.Int64(k, val))
-		case float64:
-			attrs = append(attrs, otelLog.Float64(k, val))
-		case []interface{}:
-			// Конвертируем массив в строку, так как Slice не поддерживается в текущей версии.
-			attrs = append(attrs, otelLog.String(k, fmt.Sprintf("%v", val)))
-		case map[string]interface{}:
-			// Конвертируем map в строку, так как Map не поддерживается в текущей версии.
-			attrs = append(attrs, otelLog.String(k, fmt.Sprintf("%v", val)))
-		default:
-			attrs = append(attrs, otelLog.String(k, fmt.Sprintf("%v", val)))
+// mergeAttrsAt объединяет атрибуты внутри вложенной Map по пути path.
+// Отсутствующие уровни создаются; исходные слайсы не изменяются.
+func mergeAttrsAt(base []otelLog.KeyValue, path []string, override []otelLog.KeyValue) []otelLog.KeyValue {
+	if len(path) == 0 {
+		return mergeAttrs(base, override)
+	}
+	var nested []otelLog.KeyValue
+	for _, kv := range base {
+		if kv.Key == path[0] && kv.Value.Kind() == otelLog.KindMap {
+			nested = kv.Value.AsMap()
 		}
 	}
+	merged := otelLog.Map(path[0], mergeAttrsAt(nested, path[1:], override)...)
+	return mergeAttrs(base, []otelLog.KeyValue{merged})
+}
 
-	return attrs
+// emitContext выбирает контекст для Emit: контекст вызова, а если в нём
+// нет активного спана — контекст, привязанный через With.
+func (c *SimpleOTLPCore) emitContext(fields []zapcore.Field) context.Context {
+	ctx, ok := contextFromFields(fields)
+	if !ok {
+		ctx = context.Background()
+	}
+	if c.ctx != nil && !trace.SpanContextFromContext(ctx).IsValid() {
+		return c.ctx
+	}
+	return ctx
 }
 
 // emitWithTimeout отправляет лог с таймаутом.
-func (c *SimpleOTLPCore) emitWithTimeout(record otelLog.Record) error {
-	if c.otlpLogger == nil {
+// Значения ctx (в том числе активный спан) сохраняются, отмена — нет.
+func (c *SimpleOTLPCore) emitWithTimeout(ctx context.Context, otlpLogger otelLog.Logger, record otelLog.Record) error {
+	if otlpLogger == nil {
 		return fmt.Errorf("otlp logger is nil")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), c.emitTimeout)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.emitTimeout)
 	defer cancel()
-	c.otlpLogger.Emit(ctx, record)
+	otlpLogger.Emit(ctx, record)
 	return nil
 }
+
+// scopeLogger возвращает OTLP логгер для имени zap логгера.
+func (c *SimpleOTLPCore) scopeLogger(name string) otelLog.Logger {
+	if c.scopes == nil || name == "" {
+		return c.otlpLogger
+	}
+	return c.scopes.logger(name)
+}
+
+// otlpScopes кэширует OTLP логгеры по имени scope; общий для всех
+// core, полученных через With.
+type otlpScopes struct {
+	provider otelLog.LoggerProvider
+	loggers  sync.Map // map[string]otelLog.Logger
+}
+
+func (s *otlpScopes) logger(name string) otelLog.Logger {
+	if l, ok := s.loggers.Load(name); ok {
+		return l.(otelLog.Logger)
+	}
+	l, _ := s.loggers.LoadOrStore(name, s.provider.Logger(name))
+	return l.(otelLog.Logger)
+}
@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// recordingOTLPLogger сохраняет отправленные OTLP записи.
+type recordingOTLPLogger struct {
+	embedded.Logger
+
+	mu      sync.Mutex
+	records []otelLog.Record
+}
+
+func (r *recordingOTLPLogger) Emit(_ context.Context, record otelLog.Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record)
+}
+
+func (r *recordingOTLPLogger) Enabled(context.Context, otelLog.EnabledParameters) bool {
+	return true
+}
+
+// recordAttrs возвращает атрибуты записи в виде map.
+func recordAttrs(record otelLog.Record) map[string]otelLog.Value {
+	attrs := make(map[string]otelLog.Value, record.AttributesLen())
+	record.WalkAttributes(func(kv otelLog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestSimpleOTLPCoreWithFields(t *testing.T) {
+	rec := &recordingOTLPLogger{}
+	core := NewSimpleOTLPCore(rec, nil, zapcore.DebugLevel, 0)
+	l := zap.New(core).
+		With(zap.String("service", "api"), zap.String("key", "first")).
+		With(zap.String("key", "second"))
+
+	l.Info("message", zap.String("request", "42"))
+	l.Info("override", zap.String("key", "call"))
+
+	if len(rec.records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(rec.records))
+	}
+	attrs := recordAttrs(rec.records[0])
+	if got := attrs["service"].AsString(); got != "api" {
+		t.Errorf("Expected service=api, got %q", got)
+	}
+	if got := attrs["key"].AsString(); got != "second" {
+		t.Errorf("Expected key=second, got %q", got)
+	}
+	if got := attrs["request"].AsString(); got != "42" {
+		t.Errorf("Expected request=42, got %q", got)
+	}
+	if got := recordAttrs(rec.records[1])["key"].AsString(); got != "call" {
+		t.Errorf("Expected key=call, got %q", got)
+	}
+}
+
+func TestSimpleOTLPCoreContextFields(t *testing.T) {
+	rec := &recordingOTLPLogger{}
+	l := &Logger{
+		zapLogger: zap.New(NewSimpleOTLPCore(rec, nil, zapcore.DebugLevel, 0)),
+		levels:    newLevels(zapcore.DebugLevel),
+	}
+	ctx := context.WithValue(context.Background(), traceIDKey, "12345")
+
+	l.WithContext(ctx).Info(context.Background(), "message")
+
+	if len(rec.records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(rec.records))
+	}
+	if got := recordAttrs(rec.records[0])[string(traceIDKey)].AsString(); got != "12345" {
+		t.Errorf("Expected trace_id=12345, got %q", got)
+	}
+}
+
+// memoryExporter сохраняет экспортированные SDK записи.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []otelLogSdk.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []otelLogSdk.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(context.Context) error { return nil }
+
+// spanContext создает контекст с валидным спаном.
+func spanContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	if err != nil {
+		t.Fatalf("Failed to parse trace id: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("0102030405060708")
+	if err != nil {
+		t.Fatalf("Failed to parse span id: %v", err)
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc), sc
+}
+
+func TestSimpleOTLPCoreSpanCorrelation(t *testing.T) {
+	exporter := &memoryExporter{}
+	provider := otelLogSdk.NewLoggerProvider(
+		otelLogSdk.WithProcessor(otelLogSdk.NewSimpleProcessor(exporter)),
+	)
+	l := &Logger{
+		zapLogger: zap.New(NewSimpleOTLPCore(provider.Logger("test"), nil, zapcore.DebugLevel, 0)),
+		levels:    newLevels(zapcore.DebugLevel),
+	}
+	ctx, sc := spanContext(t)
+
+	l.Info(ctx, "in span")
+	l.WithContext(ctx).Info(context.Background(), "bound span")
+	l.Info(context.Background(), "no span")
+
+	if len(exporter.records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(exporter.records))
+	}
+	for _, r := range exporter.records[:2] {
+		if r.TraceID() != sc.TraceID() || r.SpanID() != sc.SpanID() {
+			t.Errorf("Expected trace %s/%s, got %s/%s", sc.TraceID(), sc.SpanID(), r.TraceID(), r.SpanID())
+		}
+		if r.TraceFlags() != sc.TraceFlags() {
+			t.Errorf("Expected trace flags %v, got %v", sc.TraceFlags(), r.TraceFlags())
+		}
+	}
+	if r := exporter.records[2]; r.TraceID().IsValid() {
+		t.Errorf("Expected no trace id, got %s", r.TraceID())
+	}
+	// Идентификаторы спана не дублируются в атрибутах.
+	for _, r := range exporter.records[:2] {
+		r.WalkAttributes(func(kv otelLog.KeyValue) bool {
+			if kv.Key == string(traceIDKey) || kv.Key == string(spanIDKey) {
+				t.Errorf("Expected no %s attribute in OTLP record", kv.Key)
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package logger_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	logger "github.com/major1ink/otelzap"
+	"github.com/major1ink/otelzap/otelzaptest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+// newOTLPLogger создает логгер, отправляющий записи в коллектор.
+func newOTLPLogger(t *testing.T, protocol, endpoint string, opts ...logger.Option) *logger.Logger {
+	t.Helper()
+	base := []logger.Option{
+		logger.WithEnableStdout(false),
+		logger.WithEnableOTLP(true),
+		logger.WithOTLPProtocol(protocol),
+		logger.WithOTLPEndpoint(endpoint),
+		logger.WithServiceName("e2e"),
+		logger.WithResourceDetectors(logger.ResourceDetectors{}),
+		logger.WithOTLPFailurePolicy(logger.OTLPFailFast),
+	}
+	l, err := logger.NewLogger(context.Background(), append(base, opts...)...)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	return l
+}
+
+// collectorEndpoint возвращает адрес коллектора для протокола.
+func collectorEndpoint(c *otelzaptest.Collector, protocol string) string {
+	if protocol == logger.OTLPProtocolGRPC {
+		return c.GRPCEndpoint()
+	}
+	return c.HTTPEndpoint()
+}
+
+// captureOTelErrors перехватывает ошибки глобального обработчика OpenTelemetry.
+func captureOTelErrors(t *testing.T) func() []error {
+	t.Helper()
+	var mu sync.Mutex
+	var errs []error
+	prev := otel.GetErrorHandler()
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}))
+	t.Cleanup(func() { otel.SetErrorHandler(prev) })
+	return func() []error {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]error(nil), errs...)
+	}
+}
+
+var protocols = []string{logger.OTLPProtocolGRPC, logger.OTLPProtocolHTTPProtobuf}
+
+func TestOTLPEndToEnd(t *testing.T) {
+	for _, protocol := range protocols {
+		t.Run(protocol, func(t *testing.T) {
+			c := otelzaptest.NewCollector(t)
+			l := newOTLPLogger(t, protocol, collectorEndpoint(c, protocol))
+
+			traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+			spanID, _ := trace.SpanIDFromHex("0102030405060708")
+			ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			}))
+			l.Info(ctx, "order created", zap.Int("order_id", 42))
+			if err := l.Close(); err != nil {
+				t.Fatalf("Failed to close logger: %v", err)
+			}
+
+			records := c.Records()
+			if len(records) != 1 {
+				t.Fatalf("Expected 1 record, got %d", len(records))
+			}
+			r := records[0]
+			if got := r.Record.GetBody().GetStringValue(); got != "order created" {
+				t.Errorf("Expected body %q, got %q", "order created", got)
+			}
+			if got := r.Record.GetSeverityNumber(); got != logspb.SeverityNumber_SEVERITY_NUMBER_INFO {
+				t.Errorf("Expected INFO severity, got %v", got)
+			}
+			if v, ok := r.Attr("order_id"); !ok || v.GetIntValue() != 42 {
+				t.Errorf("Expected order_id=42, got %v", v)
+			}
+			if got := trace.TraceID(r.Record.GetTraceId()); got != traceID {
+				t.Errorf("Expected trace id %s, got %s", traceID, got)
+			}
+			if v, ok := r.ResourceAttr("service.name"); !ok || v.GetStringValue() != "e2e" {
+				t.Errorf("Expected service.name=e2e, got %v", v)
+			}
+			if got := r.Scope.GetName(); got != "app" {
+				t.Errorf("Expected scope app, got %q", got)
+			}
+		})
+	}
+}
+
+func TestOTLPRetryOnUnavailable(t *testing.T) {
+	for _, protocol := range protocols {
+		t.Run(protocol, func(t *testing.T) {
+			c := otelzaptest.NewCollector(t)
+			c.FailNext(1, codes.Unavailable)
+			l := newOTLPLogger(t, protocol, collectorEndpoint(c, protocol),
+				logger.WithOTLPRetry(logger.RetryConfig{
+					Enabled:         true,
+					InitialInterval: 10 * time.Millisecond,
+					MaxInterval:     50 * time.Millisecond,
+					MaxElapsedTime:  5 * time.Second,
+				}),
+			)
+
+			l.Info(context.Background(), "retried")
+			if err := l.Close(); err != nil {
+				t.Fatalf("Failed to close logger: %v", err)
+			}
+
+			if got := c.Attempts(); got != 2 {
+				t.Errorf("Expected 2 attempts, got %d", got)
+			}
+			if got := len(c.Records()); got != 1 {
+				t.Errorf("Expected 1 record after retry, got %d", got)
+			}
+		})
+	}
+}
+
+func TestOTLPPartialSuccess(t *testing.T) {
+	errs := captureOTelErrors(t)
+	c := otelzaptest.NewCollector(t)
+	c.SetPartialSuccess(1, "quota exceeded")
+	l := newOTLPLogger(t, logger.OTLPProtocolGRPC, c.GRPCEndpoint())
+
+	l.Warn(context.Background(), "partially accepted")
+	if err := l.Close(); err != nil {
+		t.Fatalf("Failed to close logger: %v", err)
+	}
+
+	if got := len(c.Records()); got != 1 {
+		t.Errorf("Expected 1 record, got %d", got)
+	}
+	var found bool
+	for _, err := range errs() {
+		found = found || strings.Contains(err.Error(), "quota exceeded")
+	}
+	if !found {
+		t.Errorf("Expected partial success error, got %v", errs())
+	}
+}
+
+func TestOTLPExportTimeout(t *testing.T) {
+	captureOTelErrors(t)
+	c := otelzaptest.NewCollector(t)
+	c.SetLatency(time.Second)
+	l := newOTLPLogger(t, logger.OTLPProtocolGRPC, c.GRPCEndpoint(),
+		logger.WithOTLPTimeout(50*time.Millisecond),
+		logger.WithOTLPRetry(logger.RetryConfig{Enabled: false}),
+	)
+
+	l.Info(context.Background(), "too slow")
+	start := time.Now()
+	_ = l.Close()
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("Expected export to time out, Close took %v", elapsed)
+	}
+	if got := len(c.Records()); got != 0 {
+		t.Errorf("Expected no records, got %d", got)
+	}
+}
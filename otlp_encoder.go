@@ -0,0 +1,280 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// otlpObjectEncoder реализует zapcore.ObjectEncoder и пишет поля напрямую в otelLog.KeyValue.
+// Вложенные объекты и массивы становятся Map/Slice, zap.Namespace — вложенной Map.
+type otlpObjectEncoder struct {
+	attrs []otelLog.KeyValue
+	index map[string]int     // Позиции ключей в attrs для замены повторов.
+	nsKey string             // Имя открытого пространства имен.
+	ns    *otlpObjectEncoder // Открытое пространство имен; новые поля пишутся в него.
+}
+
+// encodeFields кодирует поля и возвращает атрибуты вместе с путем
+// пространств имен, оставшихся открытыми после последнего поля.
+// Идентификаторы активного спана пропускаются (см. spanField).
+func encodeFields(fields []zapcore.Field) ([]otelLog.KeyValue, []string) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	enc := &otlpObjectEncoder{}
+	for _, f := range fields {
+		if isSpanField(f) {
+			continue
+		}
+		f.AddTo(enc)
+	}
+	return enc.result(), enc.namespace()
+}
+
+// target возвращает энкодер самого глубокого открытого пространства имен.
+func (e *otlpObjectEncoder) target() *otlpObjectEncoder {
+	for e.ns != nil {
+		e = e.ns
+	}
+	return e
+}
+
+// add добавляет атрибут; повторный ключ заменяет предыдущее значение.
+func (e *otlpObjectEncoder) add(key string, v otelLog.Value) {
+	t := e.target()
+	if i, ok := t.index[key]; ok {
+		t.attrs[i].Value = v
+		return
+	}
+	if t.index == nil {
+		t.index = make(map[string]int)
+	}
+	t.index[key] = len(t.attrs)
+	t.attrs = append(t.attrs, otelLog.KeyValue{Key: key, Value: v})
+}
+
+// result возвращает атрибуты с учетом пространств имен.
+func (e *otlpObjectEncoder) result() []otelLog.KeyValue {
+	if e.ns == nil {
+		return e.attrs
+	}
+	return append(e.attrs, otelLog.Map(e.nsKey, e.ns.result()...))
+}
+
+// namespace возвращает путь открытых пространств имен.
+func (e *otlpObjectEncoder) namespace() []string {
+	var path []string
+	for ; e.ns != nil; e = e.ns {
+		path = append(path, e.nsKey)
+	}
+	return path
+}
+
+func (e *otlpObjectEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	enc := &otlpArrayEncoder{}
+	err := arr.MarshalLogArray(enc)
+	e.add(key, otelLog.SliceValue(enc.values...))
+	return err
+}
+
+func (e *otlpObjectEncoder) AddObject(key string, obj zapcore.ObjectMarshaler) error {
+	enc := &otlpObjectEncoder{}
+	err := obj.MarshalLogObject(enc)
+	e.add(key, otelLog.MapValue(enc.result()...))
+	return err
+}
+
+func (e *otlpObjectEncoder) AddBinary(key string, v []byte) { e.add(key, otelLog.BytesValue(v)) }
+func (e *otlpObjectEncoder) AddByteString(key string, v []byte) {
+	e.add(key, otelLog.StringValue(string(v)))
+}
+func (e *otlpObjectEncoder) AddBool(key string, v bool) { e.add(key, otelLog.BoolValue(v)) }
+func (e *otlpObjectEncoder) AddComplex128(key string, v complex128) {
+	e.add(key, complexValue(v, 64))
+}
+func (e *otlpObjectEncoder) AddComplex64(key string, v complex64) {
+	e.add(key, complexValue(complex128(v), 32))
+}
+func (e *otlpObjectEncoder) AddDuration(key string, v time.Duration) { e.add(key, durationValue(v)) }
+func (e *otlpObjectEncoder) AddFloat64(key string, v float64)        { e.add(key, otelLog.Float64Value(v)) }
+func (e *otlpObjectEncoder) AddFloat32(key string, v float32) {
+	e.add(key, otelLog.Float64Value(float64(v)))
+}
+func (e *otlpObjectEncoder) AddInt(key string, v int)         { e.add(key, otelLog.IntValue(v)) }
+func (e *otlpObjectEncoder) AddInt64(key string, v int64)     { e.add(key, otelLog.Int64Value(v)) }
+func (e *otlpObjectEncoder) AddInt32(key string, v int32)     { e.add(key, otelLog.Int64Value(int64(v))) }
+func (e *otlpObjectEncoder) AddInt16(key string, v int16)     { e.add(key, otelLog.Int64Value(int64(v))) }
+func (e *otlpObjectEncoder) AddInt8(key string, v int8)       { e.add(key, otelLog.Int64Value(int64(v))) }
+func (e *otlpObjectEncoder) AddString(key, v string)          { e.add(key, otelLog.StringValue(v)) }
+func (e *otlpObjectEncoder) AddTime(key string, v time.Time)  { e.add(key, timeValue(v)) }
+func (e *otlpObjectEncoder) AddUint(key string, v uint)       { e.add(key, uintValue(uint64(v))) }
+func (e *otlpObjectEncoder) AddUint64(key string, v uint64)   { e.add(key, uintValue(v)) }
+func (e *otlpObjectEncoder) AddUint32(key string, v uint32)   { e.add(key, otelLog.Int64Value(int64(v))) }
+func (e *otlpObjectEncoder) AddUint16(key string, v uint16)   { e.add(key, otelLog.Int64Value(int64(v))) }
+func (e *otlpObjectEncoder) AddUint8(key string, v uint8)     { e.add(key, otelLog.Int64Value(int64(v))) }
+func (e *otlpObjectEncoder) AddUintptr(key string, v uintptr) { e.add(key, uintValue(uint64(v))) }
+
+func (e *otlpObjectEncoder) AddReflected(key string, v interface{}) error {
+	e.add(key, reflectedValue(v))
+	return nil
+}
+
+func (e *otlpObjectEncoder) OpenNamespace(key string) {
+	t := e.target()
+	t.nsKey = key
+	t.ns = &otlpObjectEncoder{}
+}
+
+// otlpArrayEncoder реализует zapcore.ArrayEncoder поверх otelLog.Value.
+type otlpArrayEncoder struct {
+	values []otelLog.Value
+}
+
+func (e *otlpArrayEncoder) append(v otelLog.Value) { e.values = append(e.values, v) }
+
+func (e *otlpArrayEncoder) AppendArray(arr zapcore.ArrayMarshaler) error {
+	enc := &otlpArrayEncoder{}
+	err := arr.MarshalLogArray(enc)
+	e.append(otelLog.SliceValue(enc.values...))
+	return err
+}
+
+func (e *otlpArrayEncoder) AppendObject(obj zapcore.ObjectMarshaler) error {
+	enc := &otlpObjectEncoder{}
+	err := obj.MarshalLogObject(enc)
+	e.append(otelLog.MapValue(enc.result()...))
+	return err
+}
+
+func (e *otlpArrayEncoder) AppendReflected(v interface{}) error {
+	e.append(reflectedValue(v))
+	return nil
+}
+
+func (e *otlpArrayEncoder) AppendBool(v bool)              { e.append(otelLog.BoolValue(v)) }
+func (e *otlpArrayEncoder) AppendByteString(v []byte)      { e.append(otelLog.StringValue(string(v))) }
+func (e *otlpArrayEncoder) AppendComplex128(v complex128)  { e.append(complexValue(v, 64)) }
+func (e *otlpArrayEncoder) AppendComplex64(v complex64)    { e.append(complexValue(complex128(v), 32)) }
+func (e *otlpArrayEncoder) AppendDuration(v time.Duration) { e.append(durationValue(v)) }
+func (e *otlpArrayEncoder) AppendFloat64(v float64)        { e.append(otelLog.Float64Value(v)) }
+func (e *otlpArrayEncoder) AppendFloat32(v float32)        { e.append(otelLog.Float64Value(float64(v))) }
+func (e *otlpArrayEncoder) AppendInt(v int)                { e.append(otelLog.IntValue(v)) }
+func (e *otlpArrayEncoder) AppendInt64(v int64)            { e.append(otelLog.Int64Value(v)) }
+func (e *otlpArrayEncoder) AppendInt32(v int32)            { e.append(otelLog.Int64Value(int64(v))) }
+func (e *otlpArrayEncoder) AppendInt16(v int16)            { e.append(otelLog.Int64Value(int64(v))) }
+func (e *otlpArrayEncoder) AppendInt8(v int8)              { e.append(otelLog.Int64Value(int64(v))) }
+func (e *otlpArrayEncoder) AppendString(v string)          { e.append(otelLog.StringValue(v)) }
+func (e *otlpArrayEncoder) AppendTime(v time.Time)         { e.append(timeValue(v)) }
+func (e *otlpArrayEncoder) AppendUint(v uint)              { e.append(uintValue(uint64(v))) }
+func (e *otlpArrayEncoder) AppendUint64(v uint64)          { e.append(uintValue(v)) }
+func (e *otlpArrayEncoder) AppendUint32(v uint32)          { e.append(otelLog.Int64Value(int64(v))) }
+func (e *otlpArrayEncoder) AppendUint16(v uint16)          { e.append(otelLog.Int64Value(int64(v))) }
+func (e *otlpArrayEncoder) AppendUint8(v uint8)            { e.append(otelLog.Int64Value(int64(v))) }
+func (e *otlpArrayEncoder) AppendUintptr(v uintptr)        { e.append(uintValue(uint64(v))) }
+
+// uintValue сохраняет uint64 как Int64, а не помещающиеся значения — строкой.
+func uintValue(v uint64) otelLog.Value {
+	if v > math.MaxInt64 {
+		return otelLog.StringValue(strconv.FormatUint(v, 10))
+	}
+	return otelLog.Int64Value(int64(v))
+}
+
+// durationValue представляет длительность в наносекундах.
+func durationValue(v time.Duration) otelLog.Value {
+	return otelLog.Int64Value(v.Nanoseconds())
+}
+
+// timeValue представляет время в формате RFC 3339 с наносекундами.
+func timeValue(v time.Time) otelLog.Value {
+	return otelLog.StringValue(v.Format(time.RFC3339Nano))
+}
+
+func complexValue(v complex128, bitSize int) otelLog.Value {
+	return otelLog.StringValue(strconv.FormatComplex(v, 'g', -1, bitSize))
+}
+
+// reflectedValue конвертирует произвольное значение в otelLog.Value.
+// Неизвестные типы проходят через JSON, чтобы сохранить структуру.
+func reflectedValue(v interface{}) otelLog.Value {
+	switch val := v.(type) {
+	case nil:
+		return otelLog.Value{}
+	case string:
+		return otelLog.StringValue(val)
+	case bool:
+		return otelLog.BoolValue(val)
+	case int:
+		return otelLog.IntValue(val)
+	case int64:
+		return otelLog.Int64Value(val)
+	case int32:
+		return otelLog.Int64Value(int64(val))
+	case uint64:
+		return uintValue(val)
+	case uint32:
+		return otelLog.Int64Value(int64(val))
+	case float64:
+		return otelLog.Float64Value(val)
+	case json.Number:
+		return numberValue(val)
+	case float32:
+		return otelLog.Float64Value(float64(val))
+	case []byte:
+		return otelLog.BytesValue(val)
+	case time.Time:
+		return timeValue(val)
+	case time.Duration:
+		return durationValue(val)
+	case []interface{}:
+		values := make([]otelLog.Value, 0, len(val))
+		for _, item := range val {
+			values = append(values, reflectedValue(item))
+		}
+		return otelLog.SliceValue(values...)
+	case map[string]interface{}:
+		kvs := make([]otelLog.KeyValue, 0, len(val))
+		for k, item := range val {
+			kvs = append(kvs, otelLog.KeyValue{Key: k, Value: reflectedValue(item)})
+		}
+		return otelLog.MapValue(kvs...)
+	case error:
+		return otelLog.StringValue(val.Error())
+	case fmt.Stringer:
+		return otelLog.StringValue(val.String())
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return otelLog.StringValue(fmt.Sprintf("%v", v))
+	}
+	// UseNumber сохраняет целые числа целыми, а не float64.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return otelLog.StringValue(string(data))
+	}
+	return reflectedValue(generic)
+}
+
+// numberValue представляет число из JSON как Int64, если оно целое, иначе как Float64.
+func numberValue(n json.Number) otelLog.Value {
+	if i, err := n.Int64(); err == nil {
+		return otelLog.Int64Value(i)
+	}
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return uintValue(u)
+	}
+	if f, err := n.Float64(); err == nil {
+		return otelLog.Float64Value(f)
+	}
+	return otelLog.StringValue(n.String())
+}
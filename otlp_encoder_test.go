@@ -0,0 +1,176 @@
+package logger
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	otelLog "go.opentelemetry.io/otel/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type testUser struct {
+	Name string
+	Tags []string
+}
+
+func (u testUser) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.Name)
+	return enc.AddArray("tags", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		for _, tag := range u.Tags {
+			arr.AppendString(tag)
+		}
+		return nil
+	}))
+}
+
+// attrMap конвертирует атрибуты в map для проверок.
+func attrMap(attrs []otelLog.KeyValue) map[string]otelLog.Value {
+	m := make(map[string]otelLog.Value, len(attrs))
+	for _, kv := range attrs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestEncodeFieldsToAttrs(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	attrs := attrMap(encodeFieldsToAttrs([]zapcore.Field{
+		zap.String("string", "value"),
+		zap.Int("int", 42),
+		zap.Uint64("uint", 7),
+		zap.Uint64("big_uint", math.MaxUint64),
+		zap.Float64("float", 1.5),
+		zap.Bool("bool", true),
+		zap.Duration("duration", 1500*time.Millisecond),
+		zap.Time("time", ts),
+		zap.Binary("binary", []byte{1, 2}),
+		zap.Strings("strings", []string{"a", "b"}),
+		zap.Object("user", testUser{Name: "bob", Tags: []string{"admin"}}),
+		zap.Any("map", map[string]int{"x": 1}),
+		zap.Error(errors.New("boom")),
+	}))
+
+	if got := attrs["string"].AsString(); got != "value" {
+		t.Errorf("Expected string=value, got %q", got)
+	}
+	if got := attrs["int"].AsInt64(); got != 42 {
+		t.Errorf("Expected int=42, got %d", got)
+	}
+	if got := attrs["uint"].AsInt64(); got != 7 {
+		t.Errorf("Expected uint=7, got %d", got)
+	}
+	if got := attrs["big_uint"].AsString(); got != "18446744073709551615" {
+		t.Errorf("Expected big_uint as string, got %v", attrs["big_uint"])
+	}
+	if got := attrs["float"].AsFloat64(); got != 1.5 {
+		t.Errorf("Expected float=1.5, got %v", got)
+	}
+	if !attrs["bool"].AsBool() {
+		t.Errorf("Expected bool=true")
+	}
+	if got := attrs["duration"].AsInt64(); got != int64(1500*time.Millisecond) {
+		t.Errorf("Expected duration in nanoseconds, got %d", got)
+	}
+	if got := attrs["time"].AsString(); got != ts.Format(time.RFC3339Nano) {
+		t.Errorf("Expected time=%s, got %q", ts.Format(time.RFC3339Nano), got)
+	}
+	if got := attrs["binary"].AsBytes(); len(got) != 2 {
+		t.Errorf("Expected 2 bytes, got %v", got)
+	}
+	if got := attrs["strings"].AsSlice(); len(got) != 2 || got[1].AsString() != "b" {
+		t.Errorf("Expected strings slice, got %v", attrs["strings"])
+	}
+	user := attrMap(attrs["user"].AsMap())
+	if got := user["name"].AsString(); got != "bob" {
+		t.Errorf("Expected user.name=bob, got %q", got)
+	}
+	if got := user["tags"].AsSlice(); len(got) != 1 || got[0].AsString() != "admin" {
+		t.Errorf("Expected user.tags=[admin], got %v", user["tags"])
+	}
+	if x := attrMap(attrs["map"].AsMap())["x"]; x.Kind() != otelLog.KindInt64 || x.AsInt64() != 1 {
+		t.Errorf("Expected map.x=1 as int, got %v", attrs["map"])
+	}
+	if got := attrs["error"].AsString(); got != "boom" {
+		t.Errorf("Expected error=boom, got %q", got)
+	}
+}
+
+func TestReflectedNumbers(t *testing.T) {
+	type order struct {
+		ID    int64   `json:"id"`
+		Big   uint64  `json:"big"`
+		Price float64 `json:"price"`
+	}
+	attrs := attrMap(encodeFieldsToAttrs([]zapcore.Field{
+		zap.Any("order", order{ID: 1 << 60, Big: math.MaxUint64, Price: 9.5}),
+	}))
+	m := attrMap(attrs["order"].AsMap())
+	if id := m["id"]; id.Kind() != otelLog.KindInt64 || id.AsInt64() != 1<<60 {
+		t.Errorf("Expected id as exact int64, got %v", id)
+	}
+	if got := m["big"].AsString(); got != "18446744073709551615" {
+		t.Errorf("Expected big as string, got %v", m["big"])
+	}
+	if price := m["price"]; price.Kind() != otelLog.KindFloat64 || price.AsFloat64() != 9.5 {
+		t.Errorf("Expected price=9.5 as float, got %v", price)
+	}
+}
+
+func TestEncodeFieldsDuplicateKeys(t *testing.T) {
+	attrs := encodeFieldsToAttrs([]zapcore.Field{
+		zap.String("a", "1"), zap.String("b", "2"), zap.String("a", "3"),
+	})
+	if len(attrs) != 2 || attrs[0].Key != "a" || attrs[0].Value.AsString() != "3" {
+		t.Errorf("Expected a=3 replacing first value in place, got %v", attrs)
+	}
+}
+
+func TestEncodeFieldsNamespace(t *testing.T) {
+	attrs, namespace := encodeFields([]zapcore.Field{
+		zap.String("top", "1"),
+		zap.Namespace("http"),
+		zap.String("method", "GET"),
+		zap.Namespace("request"),
+		zap.Int("size", 10),
+	})
+	if len(namespace) != 2 || namespace[0] != "http" || namespace[1] != "request" {
+		t.Fatalf("Expected namespace [http request], got %v", namespace)
+	}
+	m := attrMap(attrs)
+	if got := m["top"].AsString(); got != "1" {
+		t.Errorf("Expected top=1, got %q", got)
+	}
+	http := attrMap(m["http"].AsMap())
+	if got := http["method"].AsString(); got != "GET" {
+		t.Errorf("Expected http.method=GET, got %q", got)
+	}
+	if got := attrMap(http["request"].AsMap())["size"].AsInt64(); got != 10 {
+		t.Errorf("Expected http.request.size=10, got %d", got)
+	}
+}
+
+func TestSimpleOTLPCoreWithNamespace(t *testing.T) {
+	rec := &recordingOTLPLogger{}
+	l := zap.New(NewSimpleOTLPCore(rec, nil, zapcore.DebugLevel, 0)).
+		With(zap.String("service", "api"), zap.Namespace("http"), zap.String("method", "GET"))
+
+	l.Info("message", zap.Int("status", 200))
+
+	if len(rec.records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(rec.records))
+	}
+	attrs := recordAttrs(rec.records[0])
+	if got := attrs["service"].AsString(); got != "api" {
+		t.Errorf("Expected service=api, got %q", got)
+	}
+	http := attrMap(attrs["http"].AsMap())
+	if got := http["method"].AsString(); got != "GET" {
+		t.Errorf("Expected http.method=GET, got %q", got)
+	}
+	if got := http["status"].AsInt64(); got != 200 {
+		t.Errorf("Expected http.status=200, got %d", got)
+	}
+}
@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/credentials"
+)
+
+// Протоколы OTLP экспортера.
+const (
+	OTLPProtocolGRPC         = "grpc"
+	OTLPProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// Алгоритмы сжатия OTLP экспортера.
+const (
+	OTLPCompressionNone = "none"
+	OTLPCompressionGzip = "gzip"
+)
+
+// errInvalidOTLPConfig оборачивает ошибки конфигурации OTLP.
+// В отличие от ошибок подключения, они не переводят логгер в деградированный режим.
+var errInvalidOTLPConfig = errors.New("invalid OTLP configuration")
+
+// createOTLPExporter создает экспортер для OTLP по выбранному протоколу.
+func createOTLPExporter(ctx context.Context, cfg Config) (otelLogSdk.Exporter, error) {
+	tlsCfg, err := buildTLSConfig(cfg.OtlpExporter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidOTLPConfig, err)
+	}
+	if err := validateCompression(cfg.OtlpExporter.Compression); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidOTLPConfig, err)
+	}
+
+	switch strings.ToLower(cfg.OtlpProtocol) {
+	case "", OTLPProtocolGRPC:
+		return createGRPCExporter(ctx, cfg, tlsCfg)
+	case OTLPProtocolHTTPProtobuf, "http":
+		return createHTTPExporter(ctx, cfg, tlsCfg)
+	default:
+		return nil, fmt.Errorf("%w: unknown OTLP protocol: %s", errInvalidOTLPConfig, cfg.OtlpProtocol)
+	}
+}
+
+// createGRPCExporter создает gRPC экспортер для OTLP.
+func createGRPCExporter(ctx context.Context, cfg Config, tlsCfg *tls.Config) (*otlploggrpc.Exporter, error) {
+	exp := cfg.OtlpExporter
+	var opts []otlploggrpc.Option
+	if cfg.OtlpEndpoint != "" {
+		opts = append(opts, otlploggrpc.WithEndpoint(cfg.OtlpEndpoint))
+	}
+	switch {
+	case tlsCfg != nil:
+		opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	case !cfg.OtlpUseTLS:
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+	if len(exp.Headers) > 0 {
+		opts = append(opts, otlploggrpc.WithHeaders(exp.Headers))
+	}
+	if strings.EqualFold(exp.Compression, OTLPCompressionGzip) {
+		opts = append(opts, otlploggrpc.WithCompressor(OTLPCompressionGzip))
+	}
+	if exp.Timeout > 0 {
+		opts = append(opts, otlploggrpc.WithTimeout(exp.Timeout))
+	}
+	if exp.Retry != nil {
+		opts = append(opts, otlploggrpc.WithRetry(otlploggrpc.RetryConfig(*exp.Retry)))
+	}
+	return otlploggrpc.New(ctx, opts...)
+}
+
+// createHTTPExporter создает HTTP/protobuf экспортер для OTLP.
+func createHTTPExporter(ctx context.Context, cfg Config, tlsCfg *tls.Config) (*otlploghttp.Exporter, error) {
+	exp := cfg.OtlpExporter
+	var opts []otlploghttp.Option
+	if cfg.OtlpEndpoint != "" {
+		opts = append(opts, otlploghttp.WithEndpoint(cfg.OtlpEndpoint))
+	}
+	switch {
+	case tlsCfg != nil:
+		opts = append(opts, otlploghttp.WithTLSClientConfig(tlsCfg))
+	case !cfg.OtlpUseTLS:
+		opts = append(opts, otlploghttp.WithInsecure())
+	}
+	if exp.URLPath != "" {
+		opts = append(opts, otlploghttp.WithURLPath(exp.URLPath))
+	}
+	if len(exp.Headers) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(exp.Headers))
+	}
+	if strings.EqualFold(exp.Compression, OTLPCompressionGzip) {
+		opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+	if exp.Timeout > 0 {
+		opts = append(opts, otlploghttp.WithTimeout(exp.Timeout))
+	}
+	if exp.Retry != nil {
+		opts = append(opts, otlploghttp.WithRetry(otlploghttp.RetryConfig(*exp.Retry)))
+	}
+	return otlploghttp.New(ctx, opts...)
+}
+
+// validateCompression проверяет алгоритм сжатия.
+func validateCompression(compression string) error {
+	switch strings.ToLower(compression) {
+	case "", OTLPCompressionNone, OTLPCompressionGzip:
+		return nil
+	default:
+		return fmt.Errorf("unknown OTLP compression: %s", compression)
+	}
+}
+
+// buildTLSConfig создает TLS конфигурацию из файлов.
+// Возвращает nil, если ни один параметр TLS не задан.
+func buildTLSConfig(exp OTLPExporterConfig) (*tls.Config, error) {
+	if exp.CAFile == "" && exp.CertFile == "" && exp.KeyFile == "" && exp.ServerName == "" {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: exp.ServerName,
+	}
+
+	if exp.CAFile != "" {
+		pem, err := os.ReadFile(exp.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates in CA file %s", exp.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if exp.CertFile != "" || exp.KeyFile != "" {
+		if exp.CertFile == "" || exp.KeyFile == "" {
+			return nil, fmt.Errorf("both client certificate and key files are required")
+		}
+		cert, err := tls.LoadX509KeyPair(exp.CertFile, exp.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
@@ -0,0 +1,245 @@
+package logger
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// httpLogsServer принимает OTLP/HTTP запросы и декодирует protobuf.
+type httpLogsServer struct {
+	mu       sync.Mutex
+	requests []*collogspb.ExportLogsServiceRequest
+	headers  []http.Header
+	paths    []string
+}
+
+func (s *httpLogsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		body = gz
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := &collogspb.ExportLogsServiceRequest{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.headers = append(s.headers, r.Header.Clone())
+	s.paths = append(s.paths, r.URL.Path)
+	s.mu.Unlock()
+
+	resp, _ := proto.Marshal(&collogspb.ExportLogsServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(resp)
+}
+
+func TestHTTPExporter(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression string
+	}{
+		{"plain", OTLPCompressionNone},
+		{"gzip", OTLPCompressionGzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &httpLogsServer{}
+			ts := httptest.NewServer(srv)
+			defer ts.Close()
+
+			ctx := context.Background()
+			l, err := NewLogger(
+				ctx,
+				WithEnableStdout(false),
+				WithEnableOTLP(true),
+				WithOTLPProtocol(OTLPProtocolHTTPProtobuf),
+				WithOTLPEndpoint(strings.TrimPrefix(ts.URL, "http://")),
+				WithOTLPURLPath("/custom/logs"),
+				WithOTLPHeaders(map[string]string{"X-Api-Key": "secret"}),
+				WithOTLPCompression(tt.compression),
+				WithServiceName("test-service"),
+			)
+			if err != nil {
+				t.Fatalf("Failed to create logger: %v", err)
+			}
+			l.Info(ctx, "over http", zap.String("key", "value"))
+			if err := l.Close(); err != nil {
+				t.Fatalf("Failed to close: %v", err)
+			}
+
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if len(srv.requests) == 0 {
+				t.Fatalf("Expected at least one export request")
+			}
+			if srv.paths[0] != "/custom/logs" {
+				t.Errorf("Expected path /custom/logs, got %s", srv.paths[0])
+			}
+			if got := srv.headers[0].Get("X-Api-Key"); got != "secret" {
+				t.Errorf("Expected X-Api-Key=secret, got %q", got)
+			}
+			if tt.compression == OTLPCompressionGzip && srv.headers[0].Get("Content-Encoding") != "gzip" {
+				t.Errorf("Expected gzip content encoding")
+			}
+			records := srv.requests[0].GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()
+			if len(records) != 1 {
+				t.Fatalf("Expected 1 record, got %d", len(records))
+			}
+			if got := records[0].GetBody().GetStringValue(); got != "over http" {
+				t.Errorf("Expected body %q, got %q", "over http", got)
+			}
+		})
+	}
+}
+
+func TestUnknownOTLPProtocol(t *testing.T) {
+	if _, err := createOTLPExporter(context.Background(), Config{OtlpProtocol: "carrier-pigeon"}); err == nil {
+		t.Errorf("Expected error for unknown protocol")
+	}
+}
+
+func TestHTTPExporterTLS(t *testing.T) {
+	srv := &httpLogsServer{}
+	ts := httptest.NewTLSServer(srv)
+	defer ts.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+
+	ctx := context.Background()
+	l, err := NewLogger(
+		ctx,
+		WithEnableStdout(false),
+		WithEnableOTLP(true),
+		WithOTLPProtocol(OTLPProtocolHTTPProtobuf),
+		WithOTLPEndpoint(strings.TrimPrefix(ts.URL, "https://")),
+		WithOTLPCAFile(caFile),
+		WithOTLPServerName("example.com"),
+		WithOTLPTimeout(5*time.Second),
+		WithOTLPRetry(RetryConfig{Enabled: false}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	l.Info(ctx, "over https")
+	if err := l.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(srv.requests) == 0 {
+		t.Fatalf("Expected at least one export request")
+	}
+}
+
+func TestInvalidOTLPConfig(t *testing.T) {
+	dir := t.TempDir()
+	invalidPEM := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"missing CA file", WithOTLPCAFile(filepath.Join(dir, "missing.pem"))},
+		{"invalid CA file", WithOTLPCAFile(invalidPEM)},
+		{"cert without key", WithOTLPClientCert(invalidPEM, "")},
+		{"invalid client cert", WithOTLPClientCert(invalidPEM, invalidPEM)},
+		{"unknown compression", WithOTLPCompression("brotli")},
+		{"unknown processor mode", WithOTLPProcessorMode("eventual")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLogger(context.Background(), WithEnableOTLP(true), tt.opt)
+			if err == nil {
+				t.Fatalf("Expected error")
+			}
+			if !errors.Is(err, errInvalidOTLPConfig) {
+				t.Errorf("Expected invalid OTLP configuration error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestSimpleProcessorMode(t *testing.T) {
+	srv := &httpLogsServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	ctx := context.Background()
+	l, err := NewLogger(
+		ctx,
+		WithEnableStdout(false),
+		WithEnableOTLP(true),
+		WithOTLPProtocol(OTLPProtocolHTTPProtobuf),
+		WithOTLPEndpoint(strings.TrimPrefix(ts.URL, "http://")),
+		WithOTLPProcessorMode(OTLPProcessorSimple),
+		WithOTLPEmitTimeout(5*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer l.Close()
+
+	l.Info(ctx, "exported synchronously")
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(srv.requests) != 1 {
+		t.Fatalf("Expected record to be exported before Close, got %d requests", len(srv.requests))
+	}
+}
+
+func TestCreateProcessor(t *testing.T) {
+	exporter := &memoryExporter{}
+	if _, ok := createProcessor(exporter, Config{OtlpProcessorMode: OTLPProcessorSimple}).(*otelLogSdk.SimpleProcessor); !ok {
+		t.Errorf("Expected simple processor")
+	}
+	batch := createProcessor(exporter, Config{OtlpBatch: BatchConfig{
+		MaxQueueSize:       10,
+		ExportInterval:     time.Second,
+		ExportTimeout:      time.Second,
+		MaxExportBatchSize: 5,
+	}})
+	if _, ok := batch.(*otelLogSdk.BatchProcessor); !ok {
+		t.Errorf("Expected batch processor")
+	}
+	if err := batch.Shutdown(context.Background()); err != nil {
+		t.Errorf("Failed to shutdown processor: %v", err)
+	}
+}
@@ -0,0 +1,251 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap/zapcore"
+)
+
+// OTLPFailurePolicy определяет поведение, если OTLP core не удалось создать.
+// Ошибки конфигурации (например, неверные файлы TLS) всегда возвращаются из NewLogger.
+type OTLPFailurePolicy int
+
+const (
+	OTLPDegrade  OTLPFailurePolicy = iota // Продолжить без OTLP (по умолчанию).
+	OTLPFailFast                          // Вернуть ошибку из NewLogger.
+	OTLPRetry                             // Повторять в фоне и подключить OTLP при успехе.
+)
+
+// OTLPState — состояние экспорта в OTLP.
+type OTLPState int
+
+const (
+	OTLPStateDisabled OTLPState = iota // OTLP не включен.
+	OTLPStateActive                    // OTLP core работает.
+	OTLPStateDegraded                  // OTLP core не создан, записи не отправляются.
+	OTLPStateRetrying                  // OTLP core создается в фоне.
+)
+
+// String возвращает название состояния.
+func (s OTLPState) String() string {
+	switch s {
+	case OTLPStateDisabled:
+		return "disabled"
+	case OTLPStateActive:
+		return "active"
+	case OTLPStateDegraded:
+		return "degraded"
+	case OTLPStateRetrying:
+		return "retrying"
+	default:
+		return fmt.Sprintf("OTLPState(%d)", int(s))
+	}
+}
+
+// OTLPStatus описывает текущее состояние OTLP.
+type OTLPStatus struct {
+	State     OTLPState // Текущее состояние.
+	LastError error     // Последняя ошибка создания OTLP core (nil после успеха).
+	Attempts  int       // Количество попыток создания OTLP core.
+}
+
+// Интервалы между фоновыми попытками в режиме OTLPRetry.
+var (
+	otlpRetryInitialInterval = time.Second
+	otlpRetryMaxInterval     = 30 * time.Second
+)
+
+// otlpBuilder создает OTLP core и его провайдер.
+type otlpBuilder func(ctx context.Context) (zapcore.Core, *otelLogSdk.LoggerProvider, error)
+
+// otlpState хранит провайдер и статус OTLP; разделяется между всеми
+// логгерами, полученными через With/WithContext.
+type otlpState struct {
+	mu       sync.Mutex
+	status   OTLPStatus
+	provider *otelLogSdk.LoggerProvider
+	cancel   context.CancelFunc // Останавливает фоновые попытки.
+	done     chan struct{}      // Закрывается по завершении фоновых попыток.
+}
+
+// buildOTLPCore создает OTLP core и при ошибке действует согласно policy.
+func (s *otlpState) buildOTLPCore(ctx context.Context, policy OTLPFailurePolicy, build otlpBuilder) (zapcore.Core, error) {
+	core, provider, err := build(ctx)
+	switch {
+	case err == nil:
+		s.mu.Lock()
+		s.provider = provider
+		s.status = OTLPStatus{State: OTLPStateActive, Attempts: 1}
+		s.mu.Unlock()
+		return core, nil
+	case errors.Is(err, errInvalidOTLPConfig), policy == OTLPFailFast:
+		return nil, fmt.Errorf("failed to create OTLP core: %w", err)
+	case policy == OTLPRetry:
+		fmt.Fprintf(os.Stderr, "Warning: failed to create OTLP core, retrying in background: %v\n", err)
+		slot := newSwapCore(zapcore.NewNopCore())
+		s.startRetry(ctx, slot, build, err)
+		return slot, nil
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: failed to create OTLP core: %v\n", err)
+		s.mu.Lock()
+		s.status = OTLPStatus{State: OTLPStateDegraded, LastError: err, Attempts: 1}
+		s.mu.Unlock()
+		return zapcore.NewNopCore(), nil
+	}
+}
+
+// startRetry запускает фоновые попытки создания OTLP core.
+func (s *otlpState) startRetry(ctx context.Context, slot *swapCore, build otlpBuilder, firstErr error) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.mu.Lock()
+	s.status = OTLPStatus{State: OTLPStateRetrying, LastError: firstErr, Attempts: 1}
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	s.mu.Unlock()
+	go s.retry(ctx, slot, build)
+}
+
+// retry повторяет попытки с экспоненциальной паузой до успеха или остановки.
+func (s *otlpState) retry(ctx context.Context, slot *swapCore, build otlpBuilder) {
+	defer close(s.done)
+	delay := otlpRetryInitialInterval
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		core, provider, err := build(ctx)
+		s.mu.Lock()
+		s.status.Attempts++
+		if err != nil {
+			s.status.LastError = err
+			s.mu.Unlock()
+			delay = min(delay*2, otlpRetryMaxInterval)
+			continue
+		}
+		s.provider = provider
+		s.status.State = OTLPStateActive
+		s.status.LastError = nil
+		s.mu.Unlock()
+		slot.swap(core)
+		return
+	}
+}
+
+// Status возвращает копию текущего статуса.
+func (s *otlpState) Status() OTLPStatus {
+	if s == nil {
+		return OTLPStatus{State: OTLPStateDisabled}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// currentProvider возвращает провайдер, если OTLP активен.
+func (s *otlpState) currentProvider() *otelLogSdk.LoggerProvider {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.provider
+}
+
+// stopRetry останавливает фоновые попытки и дожидается их завершения.
+func (s *otlpState) stopRetry() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// swapCore — core, реальная реализация которого подключается позже.
+// Производные core (через With) используют общий слот и применяют свои поля
+// к текущей реализации при первом обращении после подмены.
+type swapCore struct {
+	slot   *atomic.Pointer[slotValue]
+	fields []zapcore.Field
+	cache  atomic.Pointer[swapCache]
+}
+
+// slotValue — текущая реализация в слоте; указатель служит версией.
+type slotValue struct {
+	core zapcore.Core
+}
+
+// swapCache — реализация с примененными полями для конкретной версии слота.
+type swapCache struct {
+	version *slotValue
+	core    zapcore.Core
+}
+
+func newSwapCore(core zapcore.Core) *swapCore {
+	slot := &atomic.Pointer[slotValue]{}
+	slot.Store(&slotValue{core: core})
+	return &swapCore{slot: slot}
+}
+
+// swap подменяет реализацию для этого core и всех производных.
+func (c *swapCore) swap(core zapcore.Core) {
+	c.slot.Store(&slotValue{core: core})
+}
+
+// current возвращает текущую реализацию с полями из With.
+func (c *swapCore) current() zapcore.Core {
+	v := c.slot.Load()
+	if cached := c.cache.Load(); cached != nil && cached.version == v {
+		return cached.core
+	}
+	core := v.core
+	if len(c.fields) > 0 {
+		core = core.With(c.fields)
+	}
+	c.cache.Store(&swapCache{version: v, core: core})
+	return core
+}
+
+// Enabled реализует zapcore.Core.
+func (c *swapCore) Enabled(level zapcore.Level) bool {
+	return c.current().Enabled(level)
+}
+
+// With реализует zapcore.Core.
+func (c *swapCore) With(fields []zapcore.Field) zapcore.Core {
+	all := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	all = append(all, c.fields...)
+	all = append(all, fields...)
+	return &swapCore{slot: c.slot, fields: all}
+}
+
+// Check реализует zapcore.Core.
+func (c *swapCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.current().Check(entry, ce)
+}
+
+// Write реализует zapcore.Core.
+func (c *swapCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.current().Write(entry, fields)
+}
+
+// Sync реализует zapcore.Core.
+func (c *swapCore) Sync() error {
+	return c.current().Sync()
+}
@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// failingBuilder возвращает ошибку первые failures попыток, затем core.
+func failingBuilder(failures int32, core zapcore.Core) (otlpBuilder, *atomic.Int32) {
+	var calls atomic.Int32
+	return func(context.Context) (zapcore.Core, *otelLogSdk.LoggerProvider, error) {
+		if n := calls.Add(1); n <= failures {
+			return nil, nil, fmt.Errorf("attempt %d: collector unavailable", n)
+		}
+		return core, otelLogSdk.NewLoggerProvider(), nil
+	}, &calls
+}
+
+func TestOTLPFailurePolicies(t *testing.T) {
+	build, _ := failingBuilder(1, zapcore.NewNopCore())
+
+	state := &otlpState{}
+	if _, err := state.buildOTLPCore(context.Background(), OTLPFailFast, build); err == nil {
+		t.Errorf("Expected error with fail-fast policy")
+	}
+
+	build, _ = failingBuilder(1, zapcore.NewNopCore())
+	state = &otlpState{}
+	if _, err := state.buildOTLPCore(context.Background(), OTLPDegrade, build); err != nil {
+		t.Fatalf("Unexpected error with degrade policy: %v", err)
+	}
+	if status := state.Status(); status.State != OTLPStateDegraded || status.LastError == nil {
+		t.Errorf("Expected degraded state with error, got %+v", status)
+	}
+
+	configErr := func(context.Context) (zapcore.Core, *otelLogSdk.LoggerProvider, error) {
+		return nil, nil, fmt.Errorf("%w: bad CA", errInvalidOTLPConfig)
+	}
+	if _, err := (&otlpState{}).buildOTLPCore(context.Background(), OTLPDegrade, configErr); !errors.Is(err, errInvalidOTLPConfig) {
+		t.Errorf("Expected configuration error regardless of policy, got %v", err)
+	}
+}
+
+func TestOTLPRetryPolicy(t *testing.T) {
+	prev := otlpRetryInitialInterval
+	otlpRetryInitialInterval = 5 * time.Millisecond
+	defer func() { otlpRetryInitialInterval = prev }()
+
+	observed, logs := observer.New(zapcore.DebugLevel)
+	build, calls := failingBuilder(2, observed)
+	state := &otlpState{}
+	core, err := state.buildOTLPCore(context.Background(), OTLPRetry, build)
+	if err != nil {
+		t.Fatalf("Unexpected error with retry policy: %v", err)
+	}
+	defer state.stopRetry()
+
+	if status := state.Status(); status.State != OTLPStateRetrying {
+		t.Errorf("Expected retrying state, got %v", status.State)
+	}
+	// Производный логгер создан до подключения OTLP.
+	l := zap.New(core).With(zap.String("service", "api"))
+	l.Info("dropped")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for state.Status().State != OTLPStateActive {
+		if time.Now().After(deadline) {
+			t.Fatalf("OTLP core was not connected, status: %+v", state.Status())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	l.Info("delivered")
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 entry after reconnect, got %d", logs.Len())
+	}
+	entry := logs.All()[0]
+	if entry.Message != "delivered" || entry.ContextMap()["service"] != "api" {
+		t.Errorf("Expected delivered entry with service field, got %+v", entry)
+	}
+	status := state.Status()
+	if status.Attempts != int(calls.Load()) || status.Attempts != 3 || status.LastError != nil {
+		t.Errorf("Expected 3 attempts without error, got %+v", status)
+	}
+	if state.currentProvider() == nil {
+		t.Errorf("Expected provider after reconnect")
+	}
+}
+
+func TestLoggerOTLPStatus(t *testing.T) {
+	l, err := NewLogger(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	if status := l.OTLPStatus(); status.State != OTLPStateDisabled {
+		t.Errorf("Expected disabled state, got %v", status.State)
+	}
+
+	l, err = NewLogger(context.Background(), WithEnableOTLP(true), WithOTLPFailurePolicy(OTLPFailFast))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer l.Close()
+	if status := l.WithFields(zap.String("key", "value")).OTLPStatus(); status.State != OTLPStateActive {
+		t.Errorf("Expected active state, got %v", status.State)
+	}
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// RecordingLogger — логгер для тестов, сохраняющий записи в памяти.
+// Записи логгеров, полученных через With/WithContext, попадают в тот же буфер.
+type RecordingLogger struct {
+	*Logger
+	logs *observer.ObservedLogs
+}
+
+// NewRecordingLogger создает логгер, записывающий все уровни начиная с debug.
+func NewRecordingLogger() *RecordingLogger {
+	lv := newLevels(zapcore.DebugLevel)
+	core, logs := observer.New(lv.global)
+	return &RecordingLogger{
+		Logger: &Logger{
+			zapLogger: zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)),
+			levels:    lv,
+			config:    Config{LogrVerbosity: defaultLogrVerbosity},
+		},
+		logs: logs,
+	}
+}
+
+// With возвращает RecordingLogger с дополнительными полями и общим буфером.
+func (r *RecordingLogger) With(fields ...zap.Field) Interface {
+	return &RecordingLogger{Logger: r.Logger.WithFields(fields...), logs: r.logs}
+}
+
+// WithContext возвращает RecordingLogger с полями из контекста и общим буфером.
+func (r *RecordingLogger) WithContext(ctx context.Context) Interface {
+	return &RecordingLogger{Logger: r.Logger.BindContext(ctx), logs: r.logs}
+}
+
+// Logs возвращает записанные сообщения.
+func (r *RecordingLogger) Logs() *observer.ObservedLogs {
+	return r.logs
+}
+
+// Entries возвращает копию записанных сообщений.
+func (r *RecordingLogger) Entries() []observer.LoggedEntry {
+	return r.logs.All()
+}
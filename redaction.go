@@ -0,0 +1,607 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultRedactionMask — замена скрытых значений по умолчанию.
+const defaultRedactionMask = "[REDACTED]"
+
+// RedactionMode определяет, чем заменяются скрытые значения.
+type RedactionMode int
+
+const (
+	RedactMask RedactionMode = iota // Заменять значение на Mask.
+	RedactHash                      // Заменять значение на HMAC-SHA256 с ключом HashSalt, чтобы записи можно было сопоставлять.
+)
+
+// Scrubber находит секреты в строковых значениях.
+type Scrubber struct {
+	Name     string                  // Название для документации и тестов.
+	Pattern  *regexp.Regexp          // Кандидаты на замену.
+	Validate func(match string) bool // Дополнительная проверка кандидата (nil — не нужна).
+}
+
+// RedactionConfig — правила скрытия данных. Применяются один раз перед
+// передачей записи во все приёмники, в том числе внутри zap.Object и вложенных map.
+type RedactionConfig struct {
+	Keys      []string      // Glob-шаблоны ключей (без учета регистра), значения которых скрываются целиком.
+	Scrubbers []Scrubber    // Поиск секретов в строковых значениях и сообщении.
+	Mode      RedactionMode // Маскирование или хеширование.
+	Mask      string        // Замена в режиме RedactMask (по умолчанию "[REDACTED]").
+	HashSalt  string        // Ключ HMAC для режима RedactHash (обязателен).
+}
+
+// DefaultRedactionKeys возвращает шаблоны ключей, которые обычно содержат секреты.
+func DefaultRedactionKeys() []string {
+	return []string{
+		"*password*", "*passwd*", "*secret*", "*token*",
+		"*api_key*", "*apikey*", "authorization", "cookie", "set-cookie",
+	}
+}
+
+// DefaultScrubbers возвращает scrubbers для JWT, номеров карт и email.
+func DefaultScrubbers() []Scrubber {
+	return []Scrubber{JWTScrubber(), CreditCardScrubber(), EmailScrubber()}
+}
+
+// DefaultRedactionConfig возвращает конфигурацию с ключами и scrubbers по умолчанию.
+func DefaultRedactionConfig() RedactionConfig {
+	return RedactionConfig{Keys: DefaultRedactionKeys(), Scrubbers: DefaultScrubbers()}
+}
+
+// JWTScrubber находит JSON Web Token.
+func JWTScrubber() Scrubber {
+	return Scrubber{
+		Name:    "jwt",
+		Pattern: regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	}
+}
+
+// CreditCardScrubber находит номера карт (13–19 цифр, допускаются пробелы
+// и дефисы) и проверяет их алгоритмом Луна.
+func CreditCardScrubber() Scrubber {
+	return Scrubber{
+		Name:     "credit_card",
+		Pattern:  regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Validate: luhnValid,
+	}
+}
+
+// EmailScrubber находит адреса электронной почты.
+func EmailScrubber() Scrubber {
+	return Scrubber{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	}
+}
+
+// luhnValid проверяет номер по алгоритму Луна; пробелы и дефисы игнорируются.
+func luhnValid(s string) bool {
+	var sum, n int
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && n <= 19 && sum%10 == 0
+}
+
+// redactor применяет RedactionConfig к полям.
+type redactor struct {
+	keys      []string
+	scrubbers []Scrubber
+	mode      RedactionMode
+	mask      string
+	salt      string
+}
+
+// newRedactor проверяет cfg. Возвращает nil, если скрытие не настроено.
+func newRedactor(cfg *RedactionConfig) (*redactor, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	r := &redactor{scrubbers: cfg.Scrubbers, mode: cfg.Mode, mask: cfg.Mask, salt: cfg.HashSalt}
+	if r.mask == "" {
+		r.mask = defaultRedactionMask
+	}
+	if r.mode == RedactHash && r.salt == "" {
+		return nil, fmt.Errorf("redaction hash mode requires HashSalt")
+	}
+	for _, k := range cfg.Keys {
+		k = strings.ToLower(k)
+		if _, err := path.Match(k, ""); err != nil {
+			return nil, fmt.Errorf("invalid redaction key pattern %q: %w", k, err)
+		}
+		r.keys = append(r.keys, k)
+	}
+	for _, s := range cfg.Scrubbers {
+		if s.Pattern == nil {
+			return nil, fmt.Errorf("redaction scrubber %q has no pattern", s.Name)
+		}
+	}
+	return r, nil
+}
+
+// wrap оборачивает core; nil redactor возвращает core без изменений.
+func (r *redactor) wrap(core zapcore.Core) zapcore.Core {
+	if r == nil {
+		return core
+	}
+	return &redactCore{Core: core, r: r}
+}
+
+// deniedKey проверяет ключ по шаблонам.
+func (r *redactor) deniedKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, p := range r.keys {
+		if ok, _ := path.Match(p, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// replacement возвращает замену для скрытого значения.
+func (r *redactor) replacement(value string) string {
+	if r.mode == RedactHash {
+		mac := hmac.New(sha256.New, []byte(r.salt))
+		mac.Write([]byte(value))
+		return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil)[:8])
+	}
+	return r.mask
+}
+
+// replaceValue возвращает замену для значения любого типа. В режиме RedactHash
+// объекты и массивы хешируются по JSON-представлению, чтобы равные значения
+// давали равные хеши.
+func (r *redactor) replaceValue(v any) string {
+	if r.mode != RedactHash {
+		return r.mask
+	}
+	return r.replacement(valueString(v))
+}
+
+// valueString кодирует значение для хеширования.
+func valueString(v any) string {
+	enc := zapcore.NewMapObjectEncoder()
+	var encoded any
+	switch m := v.(type) {
+	case zapcore.ObjectMarshaler:
+		if m.MarshalLogObject(enc) == nil {
+			encoded = enc.Fields
+		}
+	case zapcore.ArrayMarshaler:
+		if enc.AddArray("value", m) == nil {
+			encoded = enc.Fields["value"]
+		}
+	default:
+		return fmt.Sprint(v)
+	}
+	if data, err := json.Marshal(encoded); err == nil && encoded != nil {
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+// scrub заменяет найденные секреты в строке.
+func (r *redactor) scrub(s string) string {
+	for _, sc := range r.scrubbers {
+		s = sc.Pattern.ReplaceAllStringFunc(s, func(m string) string {
+			if sc.Validate != nil && !sc.Validate(m) {
+				return m
+			}
+			return r.replacement(m)
+		})
+	}
+	return s
+}
+
+// fields возвращает копию полей со скрытыми значениями.
+func (r *redactor) fields(fields []zapcore.Field) []zapcore.Field {
+	if len(fields) == 0 {
+		return fields
+	}
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		out[i] = r.field(f)
+	}
+	return out
+}
+
+// field скрывает значение поля целиком по ключу или scrubbers.
+func (r *redactor) field(f zapcore.Field) zapcore.Field {
+	switch f.Type {
+	case zapcore.SkipType, zapcore.NamespaceType:
+		return f
+	}
+	if r.deniedKey(f.Key) {
+		return zap.String(f.Key, r.replacement(fieldString(f)))
+	}
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = r.scrub(f.String) // Сохраняет метку spanField.
+		return f
+	case zapcore.ByteStringType:
+		return zap.String(f.Key, r.scrub(string(f.Interface.([]byte))))
+	case zapcore.StringerType:
+		return zap.String(f.Key, r.scrub(fmt.Sprint(f.Interface)))
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return zap.String(f.Key, r.scrub(err.Error()))
+		}
+	case zapcore.ObjectMarshalerType:
+		return zap.Object(f.Key, redactObject{m: f.Interface.(zapcore.ObjectMarshaler), r: r})
+	case zapcore.InlineMarshalerType:
+		return zap.Inline(redactObject{m: f.Interface.(zapcore.ObjectMarshaler), r: r})
+	case zapcore.ArrayMarshalerType:
+		return zap.Array(f.Key, redactArray{m: f.Interface.(zapcore.ArrayMarshaler), r: r})
+	case zapcore.ReflectType:
+		return zap.Reflect(f.Key, r.value(f.Interface))
+	}
+	return f
+}
+
+// fieldString возвращает значение поля строкой для хеширования.
+func fieldString(f zapcore.Field) string {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return fmt.Sprint(enc.Fields[f.Key])
+}
+
+// value скрывает данные в произвольном значении: строках, map, слайсах
+// и структурах (структуры приводятся к map через JSON).
+func (r *redactor) value(v any) any {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case json.Number:
+		return t
+	case string:
+		return r.scrub(t)
+	case []byte:
+		return r.scrub(string(t))
+	case error:
+		return r.scrub(t.Error())
+	case fmt.Stringer:
+		return r.scrub(t.String())
+	case zapcore.ObjectMarshaler:
+		return redactObject{m: t, r: r}
+	case zapcore.ArrayMarshaler:
+		return redactArray{m: t, r: r}
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return v
+		}
+		return r.value(rv.Elem().Interface())
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return v
+		}
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			if r.deniedKey(k) {
+				out[k] = r.replaceValue(iter.Value().Interface())
+				continue
+			}
+			out[k] = r.value(iter.Value().Interface())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		out := make([]any, rv.Len())
+		for i := range out {
+			out[i] = r.value(rv.Index(i).Interface())
+		}
+		return out
+	case reflect.Struct:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return v
+		}
+		// UseNumber сохраняет целые числа без потери точности.
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var decoded any
+		if err := dec.Decode(&decoded); err != nil {
+			return v
+		}
+		return r.value(decoded)
+	}
+	return v
+}
+
+// redactCore скрывает данные в полях и сообщении перед записью во вложенный core.
+// Оборачивает Tee целиком, поэтому запись обрабатывается один раз для всех приёмников.
+type redactCore struct {
+	zapcore.Core
+	r *redactor
+}
+
+// With реализует zapcore.Core.
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.r.fields(fields)), r: c.r}
+}
+
+// Check реализует zapcore.Core. Решение принимает Check вложенного core
+// (сэмплеры, ограничения); принятая запись пишется через redactedEntry.
+func (c *redactCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	inner := c.Core.Check(entry, nil)
+	if inner == nil {
+		return ce
+	}
+	e := &redactedEntry{ce: inner, r: c.r}
+	e.outer = ce.AddCore(entry, e)
+	return e.outer
+}
+
+// Write реализует zapcore.Core.
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = c.r.scrub(entry.Message)
+	return c.Core.Write(entry, c.r.fields(fields))
+}
+
+// redactedEntry — одноразовый core, который пишет запись, принятую Check
+// вложенного core, со скрытыми данными.
+type redactedEntry struct {
+	ce    *zapcore.CheckedEntry
+	outer *zapcore.CheckedEntry // Запись логгера; ErrorOutput задается после Check.
+	r     *redactor
+}
+
+func (e *redactedEntry) Enabled(zapcore.Level) bool        { return true }
+func (e *redactedEntry) With([]zapcore.Field) zapcore.Core { return e }
+func (e *redactedEntry) Sync() error                       { return nil }
+func (e *redactedEntry) Check(zapcore.Entry, *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return nil
+}
+
+// Write реализует zapcore.Core.
+func (e *redactedEntry) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = e.r.scrub(entry.Message)
+	e.ce.Entry = entry
+	e.ce.ErrorOutput = e.outer.ErrorOutput
+	e.ce.Write(e.r.fields(fields)...)
+	return nil
+}
+
+// redactObject скрывает данные внутри zap.Object.
+type redactObject struct {
+	m zapcore.ObjectMarshaler
+	r *redactor
+}
+
+// MarshalLogObject реализует zapcore.ObjectMarshaler.
+func (o redactObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return o.m.MarshalLogObject(&redactObjectEncoder{ObjectEncoder: enc, r: o.r})
+}
+
+// redactArray скрывает данные внутри zap.Array.
+type redactArray struct {
+	m zapcore.ArrayMarshaler
+	r *redactor
+}
+
+// MarshalLogArray реализует zapcore.ArrayMarshaler.
+func (a redactArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return a.m.MarshalLogArray(&redactArrayEncoder{ArrayEncoder: enc, r: a.r})
+}
+
+// redactObjectEncoder проверяет ключи и строковые значения перед записью
+// во вложенный энкодер.
+type redactObjectEncoder struct {
+	zapcore.ObjectEncoder
+	r *redactor
+}
+
+// deny записывает замену, если ключ запрещен.
+func (e *redactObjectEncoder) deny(key string, value any) bool {
+	if !e.r.deniedKey(key) {
+		return false
+	}
+	e.ObjectEncoder.AddString(key, e.r.replaceValue(value))
+	return true
+}
+
+func (e *redactObjectEncoder) AddArray(key string, v zapcore.ArrayMarshaler) error {
+	if e.deny(key, v) {
+		return nil
+	}
+	return e.ObjectEncoder.AddArray(key, redactArray{m: v, r: e.r})
+}
+
+func (e *redactObjectEncoder) AddObject(key string, v zapcore.ObjectMarshaler) error {
+	if e.deny(key, v) {
+		return nil
+	}
+	return e.ObjectEncoder.AddObject(key, redactObject{m: v, r: e.r})
+}
+
+func (e *redactObjectEncoder) AddBinary(key string, v []byte) {
+	if !e.deny(key, string(v)) {
+		e.ObjectEncoder.AddBinary(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddByteString(key string, v []byte) {
+	if !e.deny(key, string(v)) {
+		e.ObjectEncoder.AddString(key, e.r.scrub(string(v)))
+	}
+}
+
+func (e *redactObjectEncoder) AddBool(key string, v bool) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddBool(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddComplex128(key string, v complex128) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddComplex128(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddComplex64(key string, v complex64) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddComplex64(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddDuration(key string, v time.Duration) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddDuration(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddFloat64(key string, v float64) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddFloat64(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddFloat32(key string, v float32) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddFloat32(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddInt(key string, v int) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddInt(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddInt64(key string, v int64) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddInt64(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddInt32(key string, v int32) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddInt32(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddInt16(key string, v int16) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddInt16(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddInt8(key string, v int8) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddInt8(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddString(key, v string) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddString(key, e.r.scrub(v))
+	}
+}
+
+func (e *redactObjectEncoder) AddTime(key string, v time.Time) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddTime(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddUint(key string, v uint) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddUint(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddUint64(key string, v uint64) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddUint64(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddUint32(key string, v uint32) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddUint32(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddUint16(key string, v uint16) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddUint16(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddUint8(key string, v uint8) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddUint8(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddUintptr(key string, v uintptr) {
+	if !e.deny(key, v) {
+		e.ObjectEncoder.AddUintptr(key, v)
+	}
+}
+
+func (e *redactObjectEncoder) AddReflected(key string, v interface{}) error {
+	if e.deny(key, v) {
+		return nil
+	}
+	return e.ObjectEncoder.AddReflected(key, e.r.value(v))
+}
+
+// redactArrayEncoder скрывает данные в элементах массива.
+type redactArrayEncoder struct {
+	zapcore.ArrayEncoder
+	r *redactor
+}
+
+func (e *redactArrayEncoder) AppendString(v string) {
+	e.ArrayEncoder.AppendString(e.r.scrub(v))
+}
+
+func (e *redactArrayEncoder) AppendByteString(v []byte) {
+	e.ArrayEncoder.AppendString(e.r.scrub(string(v)))
+}
+
+func (e *redactArrayEncoder) AppendArray(v zapcore.ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(redactArray{m: v, r: e.r})
+}
+
+func (e *redactArrayEncoder) AppendObject(v zapcore.ObjectMarshaler) error {
+	return e.ArrayEncoder.AppendObject(redactObject{m: v, r: e.r})
+}
+
+func (e *redactArrayEncoder) AppendReflected(v interface{}) error {
+	return e.ArrayEncoder.AppendReflected(e.r.value(v))
+}
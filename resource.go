@@ -0,0 +1,226 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// ResourceDetectors включает отдельные детекторы атрибутов ресурса.
+type ResourceDetectors struct {
+	Host       bool // host.name, host.id.
+	OS         bool // os.type, os.description.
+	Process    bool // process.pid, process.executable.*, process.runtime.* (без аргументов командной строки).
+	BuildInfo  bool // service.version и vcs.revision из debug.ReadBuildInfo.
+	Container  bool // container.id из /proc/self/cgroup.
+	Kubernetes bool // k8s.pod.*, k8s.namespace.name, k8s.node.name из переменных downward API.
+}
+
+// DefaultResourceDetectors возвращает набор детекторов по умолчанию (все включены).
+func DefaultResourceDetectors() ResourceDetectors {
+	return ResourceDetectors{
+		Host:       true,
+		OS:         true,
+		Process:    true,
+		BuildInfo:  true,
+		Container:  true,
+		Kubernetes: true,
+	}
+}
+
+// resourceSources — источники данных для детекторов; подменяются в тестах.
+type resourceSources struct {
+	fsys      fs.FS
+	getenv    func(string) string
+	buildInfo func() (*debug.BuildInfo, bool)
+}
+
+// defaultResourceSources использует реальные файловую систему, окружение и сборку.
+func defaultResourceSources() resourceSources {
+	return resourceSources{
+		fsys:      os.DirFS("/"),
+		getenv:    os.Getenv,
+		buildInfo: debug.ReadBuildInfo,
+	}
+}
+
+// createResource создает метаданные сервиса.
+// Приоритет (от низшего): базовый ресурс (см. baseResource), детекторы, WithResource,
+// ResourceAttributes, явные ServiceName/ServiceVersion/ServiceEnvironment.
+func createResource(ctx context.Context, cfg Config) (*resource.Resource, error) {
+	return buildResource(ctx, cfg, defaultResourceSources())
+}
+
+func buildResource(ctx context.Context, cfg Config, src resourceSources) (*resource.Resource, error) {
+	det := cfg.ResourceDetectors
+	var opts []resource.Option
+	if det.Host {
+		opts = append(opts, resource.WithHost(), resource.WithHostID())
+	}
+	if det.OS {
+		opts = append(opts, resource.WithOS())
+	}
+	if det.Process {
+		opts = append(opts,
+			resource.WithProcessPID(),
+			resource.WithProcessExecutableName(),
+			resource.WithProcessExecutablePath(),
+			resource.WithProcessRuntimeName(),
+			resource.WithProcessRuntimeVersion(),
+			resource.WithProcessRuntimeDescription(),
+		)
+	}
+	var detectors []resource.Detector
+	if det.BuildInfo {
+		detectors = append(detectors, buildInfoDetector{read: src.buildInfo})
+	}
+	if det.Container {
+		detectors = append(detectors, containerDetector{fsys: src.fsys})
+	}
+	if det.Kubernetes {
+		detectors = append(detectors, kubernetesDetector{getenv: src.getenv})
+	}
+	opts = append(opts, resource.WithDetectors(detectors...))
+
+	detected, err := resource.New(ctx, opts...)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, fmt.Errorf("failed to detect resource: %w", err)
+	}
+
+	base, err := baseResource(ctx, cfg.useEnv)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(base, detected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge detected resource: %w", err)
+	}
+	if cfg.Resource != nil {
+		if res, err = resource.Merge(res, cfg.Resource); err != nil {
+			return nil, fmt.Errorf("failed to merge resource: %w", err)
+		}
+	}
+
+	attrs := append([]attribute.KeyValue{}, cfg.ResourceAttributes...)
+	if cfg.ServiceName != "" {
+		attrs = append(attrs, semconv.ServiceName(cfg.ServiceName))
+	}
+	if cfg.ServiceVersion != "" {
+		attrs = append(attrs, semconv.ServiceVersion(cfg.ServiceVersion))
+	}
+	if cfg.ServiceEnvironment != "" {
+		attrs = append(attrs, attribute.String("deployment.environment", cfg.ServiceEnvironment))
+	}
+	return resource.Merge(res, resource.NewSchemaless(attrs...))
+}
+
+// baseResource возвращает service.name по умолчанию и атрибуты telemetry.sdk.*.
+// В отличие от resource.Default(), переменные OTEL_SERVICE_NAME и
+// OTEL_RESOURCE_ATTRIBUTES учитываются только с WithEnvConfig.
+func baseResource(ctx context.Context, useEnv bool) (*resource.Resource, error) {
+	opts := []resource.Option{
+		resource.WithAttributes(semconv.ServiceName("unknown_service:" + filepath.Base(os.Args[0]))),
+		resource.WithTelemetrySDK(),
+	}
+	if useEnv {
+		opts = append(opts, resource.WithFromEnv())
+	}
+	res, err := resource.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base resource: %w", err)
+	}
+	return res, nil
+}
+
+// buildInfoDetector извлекает версию модуля и ревизию VCS из информации о сборке.
+type buildInfoDetector struct {
+	read func() (*debug.BuildInfo, bool)
+}
+
+// Detect реализует resource.Detector.
+func (d buildInfoDetector) Detect(context.Context) (*resource.Resource, error) {
+	info, ok := d.read()
+	if !ok {
+		return resource.Empty(), nil
+	}
+	var attrs []attribute.KeyValue
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		attrs = append(attrs, semconv.ServiceVersion(v))
+	}
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" && s.Value != "" {
+			attrs = append(attrs, attribute.String("vcs.revision", s.Value))
+		}
+	}
+	return resource.NewSchemaless(attrs...), nil
+}
+
+// cgroupContainerID находит 64-символьный идентификатор контейнера в строке cgroup.
+var cgroupContainerID = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// containerDetector извлекает container.id из /proc/self/cgroup.
+type containerDetector struct {
+	fsys fs.FS
+}
+
+// Detect реализует resource.Detector.
+func (d containerDetector) Detect(context.Context) (*resource.Resource, error) {
+	f, err := d.fsys.Open("proc/self/cgroup")
+	if err != nil {
+		// Файла нет вне Linux или вне контейнера — это не ошибка.
+		return resource.Empty(), nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if id := cgroupContainerID.FindString(scanner.Text()); id != "" {
+			return resource.NewSchemaless(semconv.ContainerID(id)), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cgroup: %w", err)
+	}
+	return resource.Empty(), nil
+}
+
+// kubernetesDetector извлекает атрибуты k8s из переменных downward API.
+// Для каждого атрибута проверяются переменные в порядке приоритета.
+type kubernetesDetector struct {
+	getenv func(string) string
+}
+
+// kubernetesEnv сопоставляет атрибуты k8s с переменными окружения.
+var kubernetesEnv = []struct {
+	attr func(string) attribute.KeyValue
+	vars []string
+}{
+	{semconv.K8SPodName, []string{"K8S_POD_NAME", "POD_NAME"}},
+	{semconv.K8SPodUID, []string{"K8S_POD_UID", "POD_UID"}},
+	{semconv.K8SNamespaceName, []string{"K8S_NAMESPACE_NAME", "POD_NAMESPACE"}},
+	{semconv.K8SNodeName, []string{"K8S_NODE_NAME", "NODE_NAME"}},
+}
+
+// Detect реализует resource.Detector.
+func (d kubernetesDetector) Detect(context.Context) (*resource.Resource, error) {
+	var attrs []attribute.KeyValue
+	for _, e := range kubernetesEnv {
+		for _, name := range e.vars {
+			if v := d.getenv(name); v != "" {
+				attrs = append(attrs, e.attr(v))
+				break
+			}
+		}
+	}
+	return resource.NewSchemaless(attrs...), nil
+}
@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const testContainerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// fakeResourceSources возвращает источники данных без обращения к системе.
+func fakeResourceSources(env map[string]string) resourceSources {
+	return resourceSources{
+		fsys: fstest.MapFS{
+			"proc/self/cgroup": &fstest.MapFile{
+				Data: []byte("0::/system.slice/docker-" + testContainerID + ".scope\n"),
+			},
+		},
+		getenv: fakeEnv(env),
+		buildInfo: func() (*debug.BuildInfo, bool) {
+			return &debug.BuildInfo{
+				Main:     debug.Module{Path: "example.com/app", Version: "v1.2.3"},
+				Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "abc123"}},
+			}, true
+		},
+	}
+}
+
+// resourceValue возвращает значение атрибута ресурса.
+func resourceValue(res *resource.Resource, key string) (string, bool) {
+	v, ok := res.Set().Value(attribute.Key(key))
+	return v.Emit(), ok
+}
+
+func TestBuildResource(t *testing.T) {
+	cfg := Config{
+		ServiceName:        "api",
+		ServiceEnvironment: "prod",
+		ResourceDetectors:  ResourceDetectors{BuildInfo: true, Container: true, Kubernetes: true},
+		ResourceAttributes: []attribute.KeyValue{attribute.String("team", "core")},
+		Resource:           resource.NewSchemaless(attribute.String("region", "eu"), attribute.String("team", "ignored")),
+	}
+	src := fakeResourceSources(map[string]string{
+		"K8S_POD_NAME":  "api-7d9f",
+		"POD_NAMESPACE": "payments",
+		"NODE_NAME":     "node-1",
+	})
+
+	res, err := buildResource(context.Background(), cfg, src)
+	if err != nil {
+		t.Fatalf("Failed to build resource: %v", err)
+	}
+
+	expected := map[string]string{
+		"service.name":           "api",
+		"deployment.environment": "prod",
+		"service.version":        "v1.2.3",
+		"vcs.revision":           "abc123",
+		"container.id":           testContainerID,
+		"k8s.pod.name":           "api-7d9f",
+		"k8s.namespace.name":     "payments",
+		"k8s.node.name":          "node-1",
+		"team":                   "core",
+		"region":                 "eu",
+	}
+	for key, want := range expected {
+		if got, ok := resourceValue(res, key); !ok || got != want {
+			t.Errorf("Expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if _, ok := resourceValue(res, "telemetry.sdk.name"); !ok {
+		t.Errorf("Expected default resource attributes to be merged")
+	}
+}
+
+func TestBuildResourceDisabledDetectors(t *testing.T) {
+	cfg := Config{ServiceName: "api", ServiceVersion: "v9.9.9"}
+	src := fakeResourceSources(map[string]string{"K8S_POD_NAME": "api-7d9f"})
+
+	res, err := buildResource(context.Background(), cfg, src)
+	if err != nil {
+		t.Fatalf("Failed to build resource: %v", err)
+	}
+	for _, key := range []string{"container.id", "k8s.pod.name", "vcs.revision", "host.name", "process.pid"} {
+		if v, ok := resourceValue(res, key); ok {
+			t.Errorf("Expected %s to be absent, got %q", key, v)
+		}
+	}
+	if got, _ := resourceValue(res, "service.version"); got != "v9.9.9" {
+		t.Errorf("Expected explicit service.version, got %q", got)
+	}
+}
+
+func TestContainerDetectorWithoutCgroup(t *testing.T) {
+	res, err := containerDetector{fsys: fstest.MapFS{}}.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.Len() != 0 {
+		t.Errorf("Expected empty resource, got %v", res)
+	}
+}
+
+func TestBuildResourceIgnoresEnvWithoutEnvConfig(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "from-env")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=env")
+	src := fakeResourceSources(nil)
+
+	res, err := buildResource(context.Background(), Config{}, src)
+	if err != nil {
+		t.Fatalf("Failed to build resource: %v", err)
+	}
+	if got, _ := resourceValue(res, "service.name"); !strings.HasPrefix(got, "unknown_service:") {
+		t.Errorf("Expected default service.name, got %q", got)
+	}
+	if v, ok := resourceValue(res, "team"); ok {
+		t.Errorf("Expected OTEL_RESOURCE_ATTRIBUTES to be ignored, got team=%q", v)
+	}
+
+	res, err = buildResource(context.Background(), Config{useEnv: true}, src)
+	if err != nil {
+		t.Fatalf("Failed to build resource: %v", err)
+	}
+	if got, _ := resourceValue(res, "team"); got != "env" {
+		t.Errorf("Expected team=env with env config, got %q", got)
+	}
+}
@@ -0,0 +1,312 @@
+package logger
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultSuppressedInterval — период сводки об отброшенных записях по умолчанию.
+const defaultSuppressedInterval = time.Minute
+
+// SamplingConfig — параметры сэмплирования zap. В каждом интервале Tick
+// пишутся первые Initial записей с одинаковыми уровнем и сообщением,
+// затем каждая Thereafter-я (0 — ни одной).
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+// RateLimitConfig — ограничение по алгоритму token bucket: Rate записей
+// в секунду, Burst — максимальный всплеск. Записи уровня DPanic и выше
+// не ограничиваются.
+type RateLimitConfig struct {
+	Rate  float64
+	Burst int
+}
+
+// SinkPolicy — политика отбрасывания записей для одного приёмника.
+type SinkPolicy struct {
+	Sampling  *SamplingConfig
+	RateLimit *RateLimitConfig
+}
+
+// SinkDropCounts — число записей, не дошедших до приёмника из-за его политики.
+type SinkDropCounts struct {
+	Sampled     uint64
+	RateLimited uint64
+}
+
+// DropCounts — счетчики отброшенных записей.
+type DropCounts struct {
+	Sampled uint64                  // Отброшены общим сэмплером (WithSampling) до всех приёмников.
+	Sinks   map[Sink]SinkDropCounts // Отброшены политиками приёмников.
+}
+
+// total возвращает общее число отброшенных записей.
+func (d DropCounts) total() uint64 {
+	n := d.Sampled
+	for _, s := range d.Sinks {
+		n += s.Sampled + s.RateLimited
+	}
+	return n
+}
+
+// validateSampling проверяет параметры сэмплирования.
+func validateSampling(s *SamplingConfig) error {
+	if s.Initial <= 0 || s.Thereafter < 0 || s.Tick <= 0 {
+		return fmt.Errorf("invalid sampling config: initial=%d thereafter=%d tick=%v", s.Initial, s.Thereafter, s.Tick)
+	}
+	return nil
+}
+
+// validateRateLimit проверяет параметры ограничения.
+func validateRateLimit(r *RateLimitConfig) error {
+	if r.Rate <= 0 || r.Burst <= 0 {
+		return fmt.Errorf("invalid rate limit config: rate=%v burst=%d", r.Rate, r.Burst)
+	}
+	return nil
+}
+
+// dropCounter — атомарные счетчики одного приёмника.
+type dropCounter struct {
+	sampled     atomic.Uint64
+	rateLimited atomic.Uint64
+}
+
+// samplingState применяет политики сэмплирования и хранит счетчики;
+// общий для всех логгеров, полученных через With/WithContext.
+type samplingState struct {
+	cfg     Config
+	now     func() time.Time
+	sampled atomic.Uint64
+	sinks   map[Sink]*dropCounter
+	order   []Sink         // Приёмники с политиками в порядке полей сводки.
+	raw     []zapcore.Core // Cores без политик, для сводки.
+	summary *zap.Logger    // Пишет сводку в raw; создается в start.
+
+	mu       sync.Mutex
+	reported DropCounts // Значения счетчиков на момент последней сводки.
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// newSamplingState проверяет политики из cfg. Возвращает nil, если
+// сэмплирование и ограничения не настроены.
+func newSamplingState(cfg Config) (*samplingState, error) {
+	if cfg.Sampling == nil && len(cfg.SinkPolicies) == 0 {
+		return nil, nil
+	}
+	if cfg.Sampling != nil {
+		if err := validateSampling(cfg.Sampling); err != nil {
+			return nil, err
+		}
+	}
+	s := &samplingState{cfg: cfg, now: time.Now, sinks: map[Sink]*dropCounter{}}
+	for sink, p := range cfg.SinkPolicies {
+		if sink != SinkStdout && sink != SinkOTLP && sink != SinkFile {
+			return nil, fmt.Errorf("unknown sink: %s", sink)
+		}
+		if p.Sampling != nil {
+			if err := validateSampling(p.Sampling); err != nil {
+				return nil, fmt.Errorf("%s: %w", sink, err)
+			}
+		}
+		if p.RateLimit != nil {
+			if err := validateRateLimit(p.RateLimit); err != nil {
+				return nil, fmt.Errorf("%s: %w", sink, err)
+			}
+		}
+		s.sinks[sink] = &dropCounter{}
+		s.order = append(s.order, sink)
+	}
+	slices.Sort(s.order)
+	return s, nil
+}
+
+// wrapSink применяет политику приёмника к core и запоминает исходный core.
+func (s *samplingState) wrapSink(sink Sink, core zapcore.Core) zapcore.Core {
+	if s == nil {
+		return core
+	}
+	s.raw = append(s.raw, core)
+	p, ok := s.cfg.SinkPolicies[sink]
+	if !ok {
+		return core
+	}
+	counter := s.sinks[sink]
+	if p.RateLimit != nil {
+		core = &rateLimitCore{
+			Core:    core,
+			bucket:  newTokenBucket(p.RateLimit.Rate, p.RateLimit.Burst, s.now),
+			dropped: &counter.rateLimited,
+		}
+	}
+	if p.Sampling != nil {
+		core = newSampler(core, p.Sampling, &counter.sampled)
+	}
+	return core
+}
+
+// wrapTee применяет общий сэмплер ко всем приёмникам.
+func (s *samplingState) wrapTee(core zapcore.Core) zapcore.Core {
+	if s == nil || s.cfg.Sampling == nil {
+		return core
+	}
+	return newSampler(core, s.cfg.Sampling, &s.sampled)
+}
+
+// newSampler создает сэмплер zap, считающий отброшенные записи.
+func newSampler(core zapcore.Core, cfg *SamplingConfig, dropped *atomic.Uint64) zapcore.Core {
+	hook := zapcore.SamplerHook(func(_ zapcore.Entry, dec zapcore.SamplingDecision) {
+		if dec&zapcore.LogDropped != 0 {
+			dropped.Add(1)
+		}
+	})
+	return zapcore.NewSamplerWithOptions(core, cfg.Tick, cfg.Initial, cfg.Thereafter, hook)
+}
+
+// counts возвращает текущие значения счетчиков.
+func (s *samplingState) counts() DropCounts {
+	if s == nil {
+		return DropCounts{}
+	}
+	d := DropCounts{Sampled: s.sampled.Load(), Sinks: make(map[Sink]SinkDropCounts, len(s.sinks))}
+	for sink, c := range s.sinks {
+		d.Sinks[sink] = SinkDropCounts{Sampled: c.sampled.Load(), RateLimited: c.rateLimited.Load()}
+	}
+	return d
+}
+
+// start запускает периодическую сводку об отброшенных записях.
+// Вызывается после wrapSink для всех приёмников.
+func (s *samplingState) start() {
+	if s == nil {
+		return
+	}
+	s.summary = zap.New(zapcore.NewTee(s.raw...))
+	interval := s.cfg.SuppressedInterval
+	if interval <= 0 {
+		interval = defaultSuppressedInterval
+	}
+	stop := make(chan struct{})
+	s.stop = stop
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.reportSuppressed()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// close останавливает сводку и пишет итоговую.
+func (s *samplingState) close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-s.done
+	s.reportSuppressed()
+}
+
+// reportSuppressed пишет сводку, если с прошлой сводки были отброшены записи.
+// Сводка пишется напрямую в приёмники, минуя сэмплирование и ограничения.
+func (s *samplingState) reportSuppressed() {
+	s.mu.Lock()
+	current := s.counts()
+	prev := s.reported
+	s.reported = current
+	s.mu.Unlock()
+
+	suppressed := current.total() - prev.total()
+	if suppressed == 0 {
+		return
+	}
+	fields := []zap.Field{
+		zap.Uint64("suppressed", suppressed),
+		zap.Uint64("suppressed.sampled", current.Sampled-prev.Sampled),
+	}
+	for _, sink := range s.order {
+		c, p := current.Sinks[sink], prev.Sinks[sink]
+		fields = append(fields,
+			zap.Uint64("suppressed."+string(sink)+".sampled", c.Sampled-p.Sampled),
+			zap.Uint64("suppressed."+string(sink)+".rate_limited", c.RateLimited-p.RateLimited),
+		)
+	}
+	s.summary.Warn(fmt.Sprintf("%d messages suppressed", suppressed), fields...)
+}
+
+// rateLimitCore отбрасывает записи сверх лимита token bucket.
+type rateLimitCore struct {
+	zapcore.Core
+	bucket  *tokenBucket
+	dropped *atomic.Uint64
+}
+
+// With реализует zapcore.Core. Производные core используют общий лимит.
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), bucket: c.bucket, dropped: c.dropped}
+}
+
+// Check реализует zapcore.Core.
+func (c *rateLimitCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return ce
+	}
+	if entry.Level < zapcore.DPanicLevel && !c.bucket.allow() {
+		c.dropped.Add(1)
+		return ce
+	}
+	return c.Core.Check(entry, ce)
+}
+
+// tokenBucket — потокобезопасный token bucket.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // Токенов в секунду.
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now func() time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now(), now: now}
+}
+
+// allow забирает токен, если он есть.
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
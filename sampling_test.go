@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newSampledLogger создает логгер с OTLP в память и дополнительным observer core.
+func newSampledLogger(t *testing.T, opts ...Option) (*Logger, *memoryExporter, *observer.ObservedLogs) {
+	t.Helper()
+	exporter := &memoryExporter{}
+	core, logs := observer.New(zapcore.DebugLevel)
+	base := []Option{
+		WithEnableStdout(false),
+		WithEnableOTLP(true),
+		WithOTLPExporter(exporter),
+		WithOTLPProcessorMode(OTLPProcessorSimple),
+		WithResourceDetectors(ResourceDetectors{}),
+		WithCore(core),
+	}
+	l, err := NewLogger(context.Background(), append(base, opts...)...)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+	return l, exporter, logs
+}
+
+func TestSampling(t *testing.T) {
+	l, exporter, logs := newSampledLogger(t, WithSampling(2, 0, time.Minute))
+
+	for i := 0; i < 10; i++ {
+		l.Error(context.Background(), "hot loop")
+	}
+
+	if got := logs.Len(); got != 2 {
+		t.Errorf("Expected 2 entries in core, got %d", got)
+	}
+	if got := len(exporter.records); got != 2 {
+		t.Errorf("Expected 2 OTLP records, got %d", got)
+	}
+	if got := l.DroppedCounts().Sampled; got != 8 {
+		t.Errorf("Expected 8 sampled entries, got %d", got)
+	}
+}
+
+func TestSinkSampling(t *testing.T) {
+	l, exporter, logs := newSampledLogger(t, WithSinkSampling(SinkOTLP, 1, 0, time.Minute))
+
+	for i := 0; i < 5; i++ {
+		l.Info(context.Background(), "repeated")
+	}
+
+	if got := logs.Len(); got != 5 {
+		t.Errorf("Expected unsampled core to get 5 entries, got %d", got)
+	}
+	if got := len(exporter.records); got != 1 {
+		t.Errorf("Expected 1 OTLP record, got %d", got)
+	}
+	d := l.DroppedCounts()
+	if got := d.Sinks[SinkOTLP].Sampled; got != 4 {
+		t.Errorf("Expected 4 OTLP entries sampled, got %d", got)
+	}
+	if d.Sampled != 0 {
+		t.Errorf("Expected no global sampling, got %d", d.Sampled)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	l, exporter, _ := newSampledLogger(t, WithRateLimit(SinkOTLP, 1, 3))
+
+	for i := 0; i < 5; i++ {
+		l.Info(context.Background(), "burst", zap.Int("i", i))
+	}
+
+	if got := len(exporter.records); got != 3 {
+		t.Errorf("Expected 3 OTLP records within burst, got %d", got)
+	}
+	if got := l.DroppedCounts().Sinks[SinkOTLP].RateLimited; got != 2 {
+		t.Errorf("Expected 2 rate-limited entries, got %d", got)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(2, 2, func() time.Time { return now })
+
+	if !b.allow() || !b.allow() || b.allow() {
+		t.Fatal("Expected burst of 2 tokens")
+	}
+	now = now.Add(500 * time.Millisecond)
+	if !b.allow() || b.allow() {
+		t.Error("Expected 1 token after 500ms at rate 2/s")
+	}
+	now = now.Add(time.Hour)
+	if !b.allow() || !b.allow() || b.allow() {
+		t.Error("Expected tokens capped at burst")
+	}
+}
+
+func TestSuppressedSummary(t *testing.T) {
+	l, _, logs := newSampledLogger(t,
+		WithSampling(1, 0, time.Minute),
+		WithRateLimit(SinkOTLP, 1, 1),
+		WithRateLimit(SinkStdout, 1000, 1000),
+		WithSuppressedInterval(time.Hour),
+	)
+
+	for i := 0; i < 4; i++ {
+		l.Warn(context.Background(), "flood")
+	}
+	l.Info(context.Background(), "other")
+	l.sampling.reportSuppressed()
+
+	summary := logs.FilterMessage("4 messages suppressed").All()
+	if len(summary) != 1 {
+		t.Fatalf("Expected summary entry, got %v", logs.All())
+	}
+	fields := summary[0].ContextMap()
+	if fields["suppressed.sampled"] != uint64(3) || fields["suppressed.otlp.rate_limited"] != uint64(1) {
+		t.Errorf("Unexpected summary fields: %v", fields)
+	}
+	var keys []string
+	for _, f := range summary[0].Context {
+		keys = append(keys, f.Key)
+	}
+	want := []string{
+		"suppressed", "suppressed.sampled",
+		"suppressed.otlp.sampled", "suppressed.otlp.rate_limited",
+		"suppressed.stdout.sampled", "suppressed.stdout.rate_limited",
+	}
+	if !slices.Equal(keys, want) {
+		t.Errorf("Expected summary fields in order %v, got %v", want, keys)
+	}
+
+	// Без новых отброшенных записей сводка не пишется.
+	l.sampling.reportSuppressed()
+	if got := logs.FilterMessageSnippet("suppressed").Len(); got != 1 {
+		t.Errorf("Expected single summary, got %d", got)
+	}
+}
+
+func TestInvalidSampling(t *testing.T) {
+	tests := map[string]Option{
+		"zero initial": WithSampling(0, 1, time.Second),
+		"zero tick":    WithSinkSampling(SinkStdout, 1, 1, 0),
+		"zero rate":    WithRateLimit(SinkOTLP, 0, 1),
+		"unknown sink": WithRateLimit(Sink("kafka"), 1, 1),
+	}
+	for name, opt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewLogger(context.Background(), opt); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
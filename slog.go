@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slogHandler реализует slog.Handler поверх Logger.
+// Записи проходят через те же cores (stdout и OTLP), что и у Logger.
+type slogHandler struct {
+	l *Logger
+	// fields — атрибуты из WithAttrs вместе с открытыми для них группами.
+	// Пишутся в Handle после полей контекста, чтобы zap.Namespace групп
+	// не захватывал trace_id, span_id и другие поля из ctx.
+	fields []zap.Field
+	// groups — группы из WithGroup, которые еще не открыты.
+	// Группа открывается только вместе с первым атрибутом, чтобы пустые
+	// группы не попадали в запись.
+	groups []string
+}
+
+// SlogHandler возвращает slog.Handler, пишущий через этот логгер.
+func (l *Logger) SlogHandler() slog.Handler {
+	return &slogHandler{l: l}
+}
+
+// NewSlogLogger создает slog.Logger поверх l.
+func NewSlogLogger(l *Logger) *slog.Logger {
+	return slog.New(l.SlogHandler())
+}
+
+// Enabled реализует slog.Handler.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.zapLogger.Core().Enabled(slogToZapLevel(level))
+}
+
+// Handle реализует slog.Handler.
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	ent := zapcore.Entry{
+		Level:      slogToZapLevel(record.Level),
+		Time:       record.Time,
+		LoggerName: h.l.zapLogger.Name(),
+		Message:    record.Message,
+	}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		ent.Caller = zapcore.NewEntryCaller(record.PC, frame.File, frame.Line, true)
+		ent.Caller.Function = frame.Function
+	}
+
+	core := h.l.zapLogger.Core()
+	ce := core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+
+	var attrs []zap.Field
+	record.Attrs(func(a slog.Attr) bool {
+		attrs = appendSlogAttr(attrs, a)
+		return true
+	})
+	ce.Write(h.l.contextFields(ctx, h.withFields(attrs))...)
+	return nil
+}
+
+// WithAttrs реализует slog.Handler.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var fields []zap.Field
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, a)
+	}
+	if len(fields) == 0 {
+		return h
+	}
+	return &slogHandler{l: h.l, fields: h.withFields(fields)}
+}
+
+// WithGroup реализует slog.Handler.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+	return &slogHandler{l: h.l, fields: h.fields, groups: append(groups, name)}
+}
+
+// withFields возвращает h.fields, дополненные attrs в отложенных группах.
+// Без attrs отложенные группы не открываются.
+func (h *slogHandler) withFields(attrs []zap.Field) []zap.Field {
+	if len(attrs) == 0 {
+		return h.fields
+	}
+	fields := make([]zap.Field, 0, len(h.fields)+len(h.groups)+len(attrs))
+	fields = append(fields, h.fields...)
+	for _, g := range h.groups {
+		fields = append(fields, zap.Namespace(g))
+	}
+	return append(fields, attrs...)
+}
+
+// slogToZapLevel сопоставляет уровень slog с уровнем zap.
+// Промежуточные уровни округляются вниз: slog.LevelInfo+2 — это Info.
+func slogToZapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// appendSlogAttr конвертирует атрибут slog в поля zap.
+// Пустые атрибуты и группы пропускаются, группа без имени встраивается.
+func appendSlogAttr(fields []zap.Field, a slog.Attr) []zap.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return fields
+		}
+		if a.Key == "" {
+			for _, ga := range attrs {
+				fields = appendSlogAttr(fields, ga)
+			}
+			return fields
+		}
+		return append(fields, zap.Object(a.Key, slogGroup(attrs)))
+	case slog.KindString:
+		return append(fields, zap.String(a.Key, a.Value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(a.Key, a.Value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(a.Key, a.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(a.Key, a.Value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(a.Key, a.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(a.Key, a.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(a.Key, a.Value.Time()))
+	default:
+		return append(fields, zap.Any(a.Key, a.Value.Any()))
+	}
+}
+
+// slogGroup кодирует группу slog как вложенный объект.
+type slogGroup []slog.Attr
+
+// MarshalLogObject реализует zapcore.ObjectMarshaler.
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, a := range g {
+		for _, f := range appendSlogAttr(nil, a) {
+			f.AddTo(enc)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"testing/slogtest"
+
+	otelLog "go.opentelemetry.io/otel/log"
+	otelLogSdk "go.opentelemetry.io/otel/sdk/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSlogHandlerConformance(t *testing.T) {
+	rec := NewRecordingLogger()
+	results := func() []map[string]any {
+		var out []map[string]any
+		for _, e := range rec.Entries() {
+			m := e.ContextMap()
+			m[slog.LevelKey] = e.Level
+			m[slog.MessageKey] = e.Message
+			if !e.Time.IsZero() {
+				m[slog.TimeKey] = e.Time
+			}
+			out = append(out, m)
+		}
+		return out
+	}
+	if err := slogtest.TestHandler(rec.SlogHandler(), results); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	rec := NewRecordingLogger()
+	if err := rec.SetLevel("info"); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+	l := NewSlogLogger(rec.Logger)
+
+	l.Debug("filtered")
+	l.Info("info")
+	l.Log(context.Background(), slog.LevelWarn+1, "warn")
+	l.Error("error")
+
+	entries := rec.Entries()
+	want := []zapcore.Level{zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), len(entries))
+	}
+	for i, e := range entries {
+		if e.Level != want[i] {
+			t.Errorf("Entry %d: expected level %v, got %v", i, want[i], e.Level)
+		}
+	}
+	if file := filepath.Base(entries[0].Caller.File); file != "slog_test.go" {
+		t.Errorf("Expected caller in slog_test.go, got %s", entries[0].Caller.File)
+	}
+}
+
+func TestSlogHandlerOTLP(t *testing.T) {
+	exporter := &memoryExporter{}
+	provider := otelLogSdk.NewLoggerProvider(
+		otelLogSdk.WithProcessor(otelLogSdk.NewSimpleProcessor(exporter)),
+	)
+	l := &Logger{
+		zapLogger: zap.New(NewSimpleOTLPCore(provider.Logger("test"), nil, zapcore.DebugLevel, 0)),
+		levels:    newLevels(zapcore.DebugLevel),
+	}
+	ctx, sc := spanContext(t)
+
+	NewSlogLogger(l).
+		With("service", "api").
+		WithGroup("http").
+		InfoContext(ctx, "request", "method", "GET", slog.Group("response", "status", 200))
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(exporter.records))
+	}
+	r := exporter.records[0]
+	if r.TraceID() != sc.TraceID() || r.SpanID() != sc.SpanID() {
+		t.Errorf("Expected trace %s/%s, got %s/%s", sc.TraceID(), sc.SpanID(), r.TraceID(), r.SpanID())
+	}
+
+	attrs := make(map[string]otelLog.Value)
+	r.WalkAttributes(func(kv otelLog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	if got := attrs["service"].AsString(); got != "api" {
+		t.Errorf("Expected service=api, got %q", got)
+	}
+	httpAttrs := attrMap(attrs["http"].AsMap())
+	if got := httpAttrs["method"].AsString(); got != "GET" {
+		t.Errorf("Expected http.method=GET, got %v", attrs["http"])
+	}
+	if got := attrMap(httpAttrs["response"].AsMap())["status"].AsInt64(); got != 200 {
+		t.Errorf("Expected http.response.status=200, got %v", httpAttrs["response"])
+	}
+}
+
+func TestSlogHandlerGroupKeepsContextFieldsTopLevel(t *testing.T) {
+	rec := NewRecordingLogger()
+	ctx, sc := spanContext(t)
+	ctx = ContextWithUserID(ctx, "42")
+
+	NewSlogLogger(rec.Logger).WithGroup("http").With("method", "GET").InfoContext(ctx, "request", "status", 200)
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["trace_id"] != sc.TraceID().String() || fields["span_id"] != sc.SpanID().String() || fields["user_id"] != "42" {
+		t.Errorf("Expected context fields at top level, got %v", fields)
+	}
+	http, _ := fields["http"].(map[string]any)
+	if http["method"] != "GET" || http["status"] != int64(200) {
+		t.Errorf("Expected method and status in http group, got %v", fields)
+	}
+	if _, ok := http["trace_id"]; ok {
+		t.Errorf("Expected trace_id outside of http group, got %v", http)
+	}
+}
+
+func TestWithSlogDefault(t *testing.T) {
+	prev := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	l, err := NewLogger(context.Background(), WithEnableStdout(true), WithSlogDefault(true))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer l.Close()
+
+	if _, ok := slog.Default().Handler().(*slogHandler); !ok {
+		t.Errorf("Expected slog default handler to be otelzap, got %T", slog.Default().Handler())
+	}
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"fmt"
+	"log"
+
+	"go.uber.org/zap"
+)
+
+// stdlibLoggerName — имя логгера для записей стандартного пакета log.
+const stdlibLoggerName = "stdlib"
+
+// ReplaceGlobals устанавливает логгер как zap.L()/zap.S() и перенаправляет
+// в него стандартный пакет log на уровне Info с именем "stdlib".
+// Возвращает функцию, восстанавливающую предыдущее состояние.
+func (l *Logger) ReplaceGlobals() func() {
+	// Глобальные логгеры вызываются напрямую, без методов-обёрток Logger.
+	z := l.zapLogger.WithOptions(zap.AddCallerSkip(-1))
+	undoGlobals := zap.ReplaceGlobals(z)
+	undoStdLog := zap.RedirectStdLog(z.Named(stdlibLoggerName))
+	return func() {
+		undoStdLog()
+		undoGlobals()
+	}
+}
+
+// StdLogger возвращает *log.Logger, который пишет через логгер на уровне levelStr.
+func (l *Logger) StdLogger(levelStr string) (*log.Logger, error) {
+	level, err := parseLevel(levelStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level: %w", err)
+	}
+	z := l.zapLogger.WithOptions(zap.AddCallerSkip(-1)).Named(stdlibLoggerName)
+	std, err := zap.NewStdLogAt(z, level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create std logger: %w", err)
+	}
+	return std, nil
+}
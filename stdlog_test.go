@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"log"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestReplaceGlobals(t *testing.T) {
+	rec := NewRecordingLogger()
+	undo := rec.ReplaceGlobals()
+
+	zap.L().Info("from zap")
+	log.Printf("from %s", "stdlib")
+	undo()
+	zap.L().Info("after undo")
+
+	entries := rec.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Message != "from zap" || entries[0].LoggerName != "" {
+		t.Errorf("Unexpected zap.L entry: %q (logger %q)", entries[0].Message, entries[0].LoggerName)
+	}
+	if entries[1].Message != "from stdlib" || entries[1].LoggerName != "stdlib" {
+		t.Errorf("Unexpected stdlib entry: %q (logger %q)", entries[1].Message, entries[1].LoggerName)
+	}
+	for _, e := range entries {
+		if file := filepath.Base(e.Caller.File); file != "stdlog_test.go" {
+			t.Errorf("Expected caller in stdlog_test.go, got %s", e.Caller.File)
+		}
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	rec := NewRecordingLogger()
+	std, err := rec.StdLogger("warn")
+	if err != nil {
+		t.Fatalf("Failed to create std logger: %v", err)
+	}
+	std.Print("disk almost full")
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if e := entries[0]; e.Level != zapcore.WarnLevel || e.LoggerName != "stdlib" || e.Message != "disk almost full" {
+		t.Errorf("Unexpected entry: level %v, logger %q, message %q", e.Level, e.LoggerName, e.Message)
+	}
+	if file := filepath.Base(entries[0].Caller.File); file != "stdlog_test.go" {
+		t.Errorf("Expected caller in stdlog_test.go, got %s", entries[0].Caller.File)
+	}
+
+	if _, err := rec.StdLogger("verbose"); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+}
@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SugaredLogger — обёртка над zap.SugaredLogger с поддержкой контекста.
+// Поля из контекста и корреляция OTLP со спаном работают так же, как в Logger.
+type SugaredLogger struct {
+	base  *Logger
+	sugar *zap.SugaredLogger
+}
+
+// newSugaredLogger создает SugaredLogger поверх l.
+func newSugaredLogger(l *Logger) *SugaredLogger {
+	return &SugaredLogger{
+		base: l,
+		// Дополнительный кадр — вспомогательные методы logf/logw/log.
+		sugar: l.zapLogger.WithOptions(zap.AddCallerSkip(1)).Sugar(),
+	}
+}
+
+// Desugar возвращает Logger с тем же состоянием.
+func (s *SugaredLogger) Desugar() *Logger {
+	return s.base
+}
+
+// With создает sugared логгер с дополнительными парами ключ-значение.
+func (s *SugaredLogger) With(args ...interface{}) *SugaredLogger {
+	return newSugaredLogger(s.base.withZap(s.base.zapLogger.Sugar().With(args...).Desugar()))
+}
+
+// Debug логирует аргументы на уровне Debug.
+func (s *SugaredLogger) Debug(ctx context.Context, args ...interface{}) {
+	s.log(ctx, zapcore.DebugLevel, args)
+}
+
+// Info логирует аргументы на уровне Info.
+func (s *SugaredLogger) Info(ctx context.Context, args ...interface{}) {
+	s.log(ctx, zapcore.InfoLevel, args)
+}
+
+// Warn логирует аргументы на уровне Warn.
+func (s *SugaredLogger) Warn(ctx context.Context, args ...interface{}) {
+	s.log(ctx, zapcore.WarnLevel, args)
+}
+
+// Error логирует аргументы на уровне Error.
+func (s *SugaredLogger) Error(ctx context.Context, args ...interface{}) {
+	s.log(ctx, zapcore.ErrorLevel, args)
+}
+
+// Fatal логирует аргументы на уровне Fatal и завершает программу.
+func (s *SugaredLogger) Fatal(ctx context.Context, args ...interface{}) {
+	s.log(ctx, zapcore.FatalLevel, args)
+}
+
+// Debugf форматирует сообщение и логирует на уровне Debug.
+func (s *SugaredLogger) Debugf(ctx context.Context, template string, args ...interface{}) {
+	s.logf(ctx, zapcore.DebugLevel, template, args)
+}
+
+// Infof форматирует сообщение и логирует на уровне Info.
+func (s *SugaredLogger) Infof(ctx context.Context, template string, args ...interface{}) {
+	s.logf(ctx, zapcore.InfoLevel, template, args)
+}
+
+// Warnf форматирует сообщение и логирует на уровне Warn.
+func (s *SugaredLogger) Warnf(ctx context.Context, template string, args ...interface{}) {
+	s.logf(ctx, zapcore.WarnLevel, template, args)
+}
+
+// Errorf форматирует сообщение и логирует на уровне Error.
+func (s *SugaredLogger) Errorf(ctx context.Context, template string, args ...interface{}) {
+	s.logf(ctx, zapcore.ErrorLevel, template, args)
+}
+
+// Fatalf форматирует сообщение, логирует на уровне Fatal и завершает программу.
+func (s *SugaredLogger) Fatalf(ctx context.Context, template string, args ...interface{}) {
+	s.logf(ctx, zapcore.FatalLevel, template, args)
+}
+
+// Debugw логирует сообщение с парами ключ-значение на уровне Debug.
+func (s *SugaredLogger) Debugw(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	s.logw(ctx, zapcore.DebugLevel, msg, keysAndValues)
+}
+
+// Infow логирует сообщение с парами ключ-значение на уровне Info.
+func (s *SugaredLogger) Infow(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	s.logw(ctx, zapcore.InfoLevel, msg, keysAndValues)
+}
+
+// Warnw логирует сообщение с парами ключ-значение на уровне Warn.
+func (s *SugaredLogger) Warnw(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	s.logw(ctx, zapcore.WarnLevel, msg, keysAndValues)
+}
+
+// Errorw логирует сообщение с парами ключ-значение на уровне Error.
+func (s *SugaredLogger) Errorw(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	s.logw(ctx, zapcore.ErrorLevel, msg, keysAndValues)
+}
+
+// Fatalw логирует сообщение с парами ключ-значение на уровне Fatal и завершает программу.
+func (s *SugaredLogger) Fatalw(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	s.logw(ctx, zapcore.FatalLevel, msg, keysAndValues)
+}
+
+// Sync сбрасывает буферы логгера.
+func (s *SugaredLogger) Sync() error {
+	return s.sugar.Sync()
+}
+
+// enabled сообщает, нужно ли форматировать сообщение уровня level.
+// Fatal и panic-уровни обрабатываются всегда.
+func (s *SugaredLogger) enabled(level zapcore.Level) bool {
+	return level >= zapcore.DPanicLevel || s.sugar.Desugar().Core().Enabled(level)
+}
+
+func (s *SugaredLogger) log(ctx context.Context, level zapcore.Level, args []interface{}) {
+	if !s.enabled(level) {
+		return
+	}
+	s.sugar.Logw(level, fmt.Sprint(args...), s.contextArgs(ctx, nil)...)
+}
+
+func (s *SugaredLogger) logf(ctx context.Context, level zapcore.Level, template string, args []interface{}) {
+	if !s.enabled(level) {
+		return
+	}
+	s.sugar.Logw(level, fmt.Sprintf(template, args...), s.contextArgs(ctx, nil)...)
+}
+
+func (s *SugaredLogger) logw(ctx context.Context, level zapcore.Level, msg string, keysAndValues []interface{}) {
+	if !s.enabled(level) {
+		return
+	}
+	s.sugar.Logw(level, msg, s.contextArgs(ctx, keysAndValues)...)
+}
+
+// contextArgs добавляет поля из контекста перед парами ключ-значение,
+// чтобы ключ без значения в конце keysAndValues не забрал поле контекста.
+func (s *SugaredLogger) contextArgs(ctx context.Context, keysAndValues []interface{}) []interface{} {
+	fields := s.base.contextFields(ctx, nil)
+	args := make([]interface{}, 0, len(keysAndValues)+len(fields))
+	for _, f := range fields {
+		args = append(args, f)
+	}
+	return append(args, keysAndValues...)
+}
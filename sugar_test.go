@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestSugaredLogger(t *testing.T) {
+	rec := NewRecordingLogger()
+	sugar := rec.Sugar().With("component", "billing")
+
+	ctx := ContextWithTraceID(context.Background(), "12345")
+	sugar.Infof(ctx, "charged %d", 10)
+	sugar.Errorw(ctx, "failed", "amount", 20)
+	sugar.Warn(ctx, "low ", "balance")
+
+	entries := rec.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].Message != "charged 10" || entries[2].Message != "low balance" {
+		t.Errorf("Unexpected messages: %q, %q", entries[0].Message, entries[2].Message)
+	}
+	for _, e := range entries {
+		fields := e.ContextMap()
+		if fields["component"] != "billing" || fields["trace_id"] != "12345" {
+			t.Errorf("Expected component and trace_id fields, got %v", fields)
+		}
+		if file := filepath.Base(e.Caller.File); file != "sugar_test.go" {
+			t.Errorf("Expected caller in sugar_test.go, got %s", e.Caller.File)
+		}
+	}
+	if got := entries[1].ContextMap()["amount"]; got != int64(20) {
+		t.Errorf("Expected amount=20, got %v", got)
+	}
+}
+
+func TestSugaredLoggerDanglingKey(t *testing.T) {
+	rec := NewRecordingLogger()
+	ctx := ContextWithTraceID(context.Background(), "12345")
+	rec.Sugar().Infow(ctx, "odd", "amount", 20, "dangling")
+
+	entries := rec.Logs().FilterMessage("odd").All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["trace_id"] != "12345" || fields["amount"] != int64(20) {
+		t.Errorf("Expected trace_id and amount to survive a dangling key, got %v", fields)
+	}
+}
+
+func TestSugaredLoggerDesugar(t *testing.T) {
+	rec := NewRecordingLogger()
+	l := rec.Sugar().With("component", "billing").Desugar()
+	if l.levels != rec.levels {
+		t.Error("Expected Desugar to keep levels")
+	}
+
+	l.Info(context.Background(), "desugared")
+	entries := rec.Entries()
+	if len(entries) != 1 || entries[0].ContextMap()["component"] != "billing" {
+		t.Fatalf("Expected entry with component field, got %v", entries)
+	}
+	if file := filepath.Base(entries[0].Caller.File); file != "sugar_test.go" {
+		t.Errorf("Expected caller in sugar_test.go, got %s", entries[0].Caller.File)
+	}
+
+	if err := rec.SetLevel("error"); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+	l.Sugar().Infof(context.Background(), "filtered %d", 1)
+	if got := rec.Logs().FilterMessage("filtered 1").Len(); got != 0 {
+		t.Errorf("Expected info message to be filtered, got %d", got)
+	}
+}